@@ -11,6 +11,8 @@ import (
 type Benchmarker struct {
 	reqs int
 	max  int
+	// rate is the maximum amount of requests dispatched per second, 0 means unlimited
+	rate int
 }
 
 // BenchMetric contains information about the request
@@ -25,6 +27,15 @@ func NewBenchmarker(requestsToSend int, maxConcurrent int) *Benchmarker {
 	}
 }
 
+// NewRateLimitedBenchmarker is the same as NewBenchmarker but will only dispatch perSecond requests every second
+func NewRateLimitedBenchmarker(requestsToSend int, maxConcurrent int, perSecond int) *Benchmarker {
+	return &Benchmarker{
+		reqs: requestsToSend,
+		max:  maxConcurrent,
+		rate: perSecond,
+	}
+}
+
 type GenerateRequestFunc func() *http.Request
 
 // Response is the response from the request
@@ -40,7 +51,15 @@ type Response struct {
 // once done it will close the requestchannel and trigger the consumer to exit
 func (bm *Benchmarker) Dispatcher(reqChan chan *http.Request, generateRequest GenerateRequestFunc) {
 	defer close(reqChan)
+	var ticker *time.Ticker
+	if bm.rate > 0 {
+		ticker = time.NewTicker(time.Second / time.Duration(bm.rate))
+		defer ticker.Stop()
+	}
 	for i := 0; i < bm.reqs; i++ {
+		if ticker != nil {
+			<-ticker.C
+		}
 		reqChan <- generateRequest()
 	}
 }
@@ -62,13 +81,21 @@ func (bm *Benchmarker) Worker(t *http.Transport, reqChan chan *http.Request, res
 		resp, err := t.RoundTrip(req)
 
 		duration := time.Since(start)
-		if resp.StatusCode != http.StatusOK {
-			err = errors.New(resp.Status)
+		if err != nil {
+			// No response to read, report the failure and move on
+			respChan <- Response{
+				Err: err,
+				Metric: BenchMetric{
+					Duration: duration.Nanoseconds(),
+				},
+			}
+			continue
 		}
-
 		data, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			err = errors.New(err.Error())
+		resp.Body.Close()
+		// A failed status counts as a failure, GET endpoints answer errors with a status code
+		if err == nil && resp.StatusCode != http.StatusOK {
+			err = errors.New(resp.Status)
 		}
 		response := Response{
 			Err:    err,
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"percybolmer/rpc-shard-testing/rpctester/harmony"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var addressCMD = &cobra.Command{
+	Use:   "address <address>...",
+	Short: "Convert addresses between one1 bech32 and 0x hex and inspect them",
+	Long: `Convert every address between its one1 bech32 and 0x hex form. Bech32 checksums and EIP-55
+checksums of mixed case hex addresses are validated, malformed addresses are reported and make the command fail.
+With --fetch the balance and nonce on every shard and the delegations on the beacon shard are fetched as well.`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  inspectAddresses,
+}
+
+var (
+	addressFetch bool
+	addressJSON  bool
+)
+
+func init() {
+	rootCmd.AddCommand(addressCMD)
+
+	addressCMD.Flags().BoolVarP(&addressFetch, "fetch", "f", false, "Fetch the balance, nonce and delegations of the addresses")
+	addressCMD.Flags().BoolVarP(&addressJSON, "json", "j", false, "Print the result as JSON")
+}
+
+func inspectAddresses(cmd *cobra.Command, args []string) {
+	summaries := make([]*harmony.AccountSummary, 0, len(args))
+	invalid := 0
+	for _, arg := range args {
+		addr, err := harmony.ParseStrict(arg)
+		if err != nil {
+			log.Printf("Invalid address: %v", err)
+			invalid++
+			continue
+		}
+		summary := &harmony.AccountSummary{Hex: addr.Hex(), Bech32: harmony.ToBech32(addr)}
+		if addressFetch {
+			summary, err = harmony.GetAccountSummary(addr)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+
+	if addressJSON {
+		data, err := json.MarshalIndent(summaries, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(string(data))
+	} else {
+		printAddressSummaries(summaries)
+	}
+	if invalid > 0 {
+		os.Exit(1)
+	}
+}
+
+func printAddressSummaries(summaries []*harmony.AccountSummary) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, summary := range summaries {
+		fmt.Fprintf(w, "%s\t%s\n", summary.Bech32, summary.Hex)
+		for _, shard := range summary.Shards {
+			if shard.Error != "" {
+				fmt.Fprintf(w, "  shard %d\terror: %s\n", shard.ShardID, shard.Error)
+				continue
+			}
+			fmt.Fprintf(w, "  shard %d\tbalance %s\tnonce %d\n", shard.ShardID, shard.Balance, shard.Nonce)
+		}
+		if addressFetch {
+			d := summary.Delegations
+			if d.Error != "" {
+				fmt.Fprintf(w, "  delegations\terror: %s\n", d.Error)
+			} else {
+				fmt.Fprintf(w, "  delegations\t%d validators\tamount %s\treward %s\tundelegating %s\n", d.Validators, d.Amount, d.Reward, d.Undelegating)
+			}
+		}
+	}
+	w.Flush()
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"percybolmer/rpc-shard-testing/rpctester/harmony"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+)
+
+var callCMD = &cobra.Command{
+	Use:   "call <method> [params...]",
+	Short: "Send a single RPC call and print the decoded result",
+	Long: `Send one RPC call to NET_URL, or --url, and pretty print the result decoded into the type from the methods registry.
+Params are parsed as JSON, or as shorthands: bech32 and hex addresses, block tags such as latest, and decimal or hex numbers.
+Block numbers and indexes are converted to hex for V1 and eth methods and to integers for V2 methods, epochs and pages are always integers.
+Method names complete in bash and zsh, see the completion command.
+
+  rpctester call hmyv2_getBalance one1pdv9lrdwl0rg5vglh4xtyrv3wjk3wsqket7zxy
+  rpctester call hmy_getBlockByNumber 1000 true
+  rpctester call eth_getBalance one1pdv9lrdwl0rg5vglh4xtyrv3wjk3wsqket7zxy latest --watch 5s`,
+	Args:      cobra.MinimumNArgs(1),
+	ValidArgs: methods.All,
+	Run:       callRPC,
+}
+
+var (
+	callURL    string
+	callRepeat int
+	callWatch  time.Duration
+	callRaw    bool
+)
+
+func init() {
+	rootCmd.AddCommand(callCMD)
+
+	callCMD.Flags().StringVarP(&callURL, "url", "u", "", "The RPC endpoint to call, defaults to NET_URL")
+	callCMD.Flags().IntVarP(&callRepeat, "repeat", "r", 1, "The amount of times to send the call")
+	callCMD.Flags().DurationVarP(&callWatch, "watch", "w", 0, "Send the call every interval until stopped, such as 5s")
+	callCMD.Flags().BoolVar(&callRaw, "raw", false, "Print the raw result instead of decoding it")
+}
+
+func callRPC(cmd *cobra.Command, args []string) {
+	method := args[0]
+	m, registered := methods.Lookup(method)
+	if !registered {
+		log.Printf("%s is not in the methods registry, params are sent as given and the result is not decoded", method)
+	}
+	params, err := parseCallParams(m, args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	if callURL == "" {
+		callURL = harmony.URL
+	}
+
+	for i := 1; callWatch > 0 || i <= callRepeat; i++ {
+		if i > 1 && callWatch > 0 {
+			time.Sleep(callWatch)
+		}
+		callOnce(method, params)
+	}
+}
+
+// callOnce sends the call and prints the decoded result with the latency
+func callOnce(method string, params []interface{}) {
+	start := time.Now()
+	resp, err := harmony.CallMethod(callURL, method, params, nil)
+	latency := time.Since(start)
+	if err != nil {
+		log.Printf("%s failed after %s: %v", method, latency, err)
+		if resp != nil && resp.Error != nil && len(resp.Error.Data) > 0 {
+			log.Printf("error data: %s", resp.Error.Data)
+		}
+		return
+	}
+
+	var result interface{} = &resp.Result
+	if !callRaw {
+		decoded := harmony.NewResult(method)
+		if err := json.Unmarshal(resp.Result, decoded); err != nil {
+			log.Printf("result does not decode into the registered type, printing it raw: %v", err)
+		} else {
+			result = decoded
+		}
+	}
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(string(data))
+	log.Printf("%s answered in %s", method, latency)
+}
+
+// parseCallParams turns the command line params into values using the registered param types
+func parseCallParams(m methods.Method, args []string) ([]interface{}, error) {
+	params := make([]interface{}, 0, len(args))
+	for i, arg := range args {
+		var param methods.Param
+		if i < len(m.Params) {
+			param = m.Params[i]
+		}
+		value, err := parseCallParam(m, param, arg)
+		if err != nil {
+			return nil, fmt.Errorf("param %d %q: %w", i+1, arg, err)
+		}
+		params = append(params, value)
+	}
+	return params, nil
+}
+
+// parseCallParam parses a single param as JSON or as a shorthand
+func parseCallParam(m methods.Method, param methods.Param, arg string) (interface{}, error) {
+	switch {
+	case strings.HasPrefix(arg, "{"), strings.HasPrefix(arg, "["), strings.HasPrefix(arg, `"`):
+		if !json.Valid([]byte(arg)) {
+			return nil, fmt.Errorf("invalid JSON")
+		}
+		return json.RawMessage(arg), nil
+	case arg == "true", arg == "false":
+		return arg == "true", nil
+	case arg == "null":
+		return nil, nil
+	case strings.HasPrefix(arg, harmony.Bech32AddressHRP+"1"):
+		addr, err := harmony.Bech32ToAddress(arg)
+		if err != nil {
+			return nil, err
+		}
+		// The eth namespace only accepts hex addresses
+		if m.Namespace == "eth" {
+			return addr.Hex(), nil
+		}
+		return arg, nil
+	}
+
+	if !isNumberParam(param.Type) {
+		return arg, nil
+	}
+	var (
+		n   uint64
+		err error
+	)
+	if strings.HasPrefix(arg, "0x") {
+		n, err = hexutil.DecodeUint64(arg)
+	} else if arg != "" && arg[0] >= '0' && arg[0] <= '9' {
+		n, err = strconv.ParseUint(arg, 10, 64)
+	} else {
+		// Block tags such as latest
+		return arg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// V1 and eth take block numbers and indexes hex encoded, V2 takes them as integers
+	// Epochs and pages are integers in every version
+	if m.Version != 2 && isHexParam(param.Type) {
+		return hexutil.EncodeUint64(n), nil
+	}
+	return n, nil
+}
+
+func isNumberParam(t methods.ParamType) bool {
+	switch t {
+	case methods.ParamBlockNumber, methods.ParamIndex, methods.ParamEpoch, methods.ParamInt:
+		return true
+	}
+	return false
+}
+
+// isHexParam reports whether V1 and eth methods take the number param hex encoded
+func isHexParam(t methods.ParamType) bool {
+	return t == methods.ParamBlockNumber || t == methods.ParamIndex
+}
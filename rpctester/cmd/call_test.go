@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"encoding/json"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"reflect"
+	"testing"
+)
+
+func TestParseCallParams(t *testing.T) {
+	type testcase struct {
+		name     string
+		method   string
+		args     []string
+		expected []interface{}
+		wantErr  bool
+	}
+
+	testCases := []testcase{
+		{
+			name:     "eth_bech32_to_hex",
+			method:   methods.METHOD_eth_getBalance,
+			args:     []string{"one155jp2y76nazx8uw5sa94fr0m4s5aj8e5xm6fu3", "latest"},
+			expected: []interface{}{"0xA5241513DA9F4463F1d4874b548dFBAC29D91f34", "latest"},
+		}, {
+			name:     "v1_keeps_bech32",
+			method:   methods.METHOD_V1_getBalance,
+			args:     []string{"one155jp2y76nazx8uw5sa94fr0m4s5aj8e5xm6fu3", "latest"},
+			expected: []interface{}{"one155jp2y76nazx8uw5sa94fr0m4s5aj8e5xm6fu3", "latest"},
+		}, {
+			name:     "eth_block_tag",
+			method:   methods.METHOD_eth_getBlockByNumber,
+			args:     []string{"pending", "false"},
+			expected: []interface{}{"pending", false},
+		}, {
+			name:     "v1_decimal_block_number",
+			method:   methods.METHOD_transaction_V1_getBlockByNumber,
+			args:     []string{"1000", "true"},
+			expected: []interface{}{"0x3e8", true},
+		}, {
+			name:     "v1_hex_block_number",
+			method:   methods.METHOD_transaction_V1_getBlockByNumber,
+			args:     []string{"0x3e8", "true"},
+			expected: []interface{}{"0x3e8", true},
+		}, {
+			name:     "v2_decimal_block_number",
+			method:   methods.METHOD_transaction_V2_getTransactionByBlockNumberAndIndex,
+			args:     []string{"1000", "2"},
+			expected: []interface{}{uint64(1000), uint64(2)},
+		}, {
+			name:     "v2_hex_block_number",
+			method:   methods.METHOD_transaction_V2_getTransactionByBlockNumberAndIndex,
+			args:     []string{"0x3e8", "0x2"},
+			expected: []interface{}{uint64(1000), uint64(2)},
+		}, {
+			name:     "eth_index",
+			method:   methods.METHOD_eth_getTransactionByBlockNumberAndIndex,
+			args:     []string{"1000", "2"},
+			expected: []interface{}{"0x3e8", "0x2"},
+		}, {
+			name:     "v1_epoch",
+			method:   methods.METHOD_staking_V1_getValidators,
+			args:     []string{"5"},
+			expected: []interface{}{uint64(5)},
+		}, {
+			name:     "v1_hex_epoch",
+			method:   methods.METHOD_protocol_epochLastBlock,
+			args:     []string{"0xa"},
+			expected: []interface{}{uint64(10)},
+		}, {
+			name:     "v2_epoch",
+			method:   methods.METHOD_staking_V2_getValidators,
+			args:     []string{"5"},
+			expected: []interface{}{uint64(5)},
+		}, {
+			name:     "page",
+			method:   methods.METHOD_staking_getAllValidatorInformation,
+			args:     []string{"0"},
+			expected: []interface{}{uint64(0)},
+		}, {
+			name:     "json_passthrough",
+			method:   methods.METHOD_transaction_V2_getBlockByNumber,
+			args:     []string{"1000", `{"fullTx":true}`},
+			expected: []interface{}{uint64(1000), json.RawMessage(`{"fullTx":true}`)},
+		}, {
+			name:     "null",
+			method:   methods.METHOD_eth_getBalance,
+			args:     []string{"null"},
+			expected: []interface{}{nil},
+		}, {
+			name:    "invalid_json",
+			method:  methods.METHOD_transaction_V2_getBlockByNumber,
+			args:    []string{"1000", `{"fullTx":`},
+			wantErr: true,
+		}, {
+			name:    "invalid_bech32",
+			method:  methods.METHOD_eth_getBalance,
+			args:    []string{"one155jp2y76nazx8uw5sa94fr0m4s5aj8e5xm6fu4", "latest"},
+			wantErr: true,
+		}, {
+			name:    "number_overflow",
+			method:  methods.METHOD_transaction_V1_getBlockByNumber,
+			args:    []string{"18446744073709551616", "true"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, ok := methods.Lookup(tc.method)
+			if !ok {
+				t.Fatalf("%s is not in the methods registry", tc.method)
+			}
+			params, err := parseCallParams(m, tc.args)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("parsing %q returned %v, expected an error", tc.args, params)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(params, tc.expected) {
+				t.Errorf("parsing %q returned %#v, expected %#v", tc.args, params, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var completionCMD = &cobra.Command{
+	Use:   "completion [bash|zsh]",
+	Short: "Generate shell completion, which completes the method names of the call command",
+	Long: `Print a completion script for bash or zsh.
+
+  source <(rpctester completion bash)`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"bash", "zsh"},
+	Run:       generateCompletion,
+}
+
+func init() {
+	rootCmd.AddCommand(completionCMD)
+}
+
+func generateCompletion(cmd *cobra.Command, args []string) {
+	var err error
+	switch args[0] {
+	case "bash":
+		err = rootCmd.GenBashCompletion(os.Stdout)
+	case "zsh":
+		err = rootCmd.GenZshCompletion(os.Stdout)
+	default:
+		log.Fatalf("Unsupported shell %s, use bash or zsh", args[0])
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
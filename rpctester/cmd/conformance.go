@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"percybolmer/rpc-shard-testing/rpctester/harmony"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var conformanceCMD = &cobra.Command{
+	Use:   "conformance",
+	Short: "Score RPC endpoints against the JSON-RPC 2.0 specification",
+	Long: `Send raw requests to every endpoint to check id echoing, notifications, the standard error codes,
+batches, the content-type header and GET versus POST handling. Every endpoint gets a score of passed checks.`,
+	Run: checkConformance,
+}
+
+var conformanceEndpoints []string
+
+func init() {
+	rootCmd.AddCommand(conformanceCMD)
+
+	conformanceCMD.Flags().StringSliceVarP(&conformanceEndpoints, "endpoints", "e", nil, "Comma separated RPC endpoints to score, defaults to NET_URL")
+}
+
+func checkConformance(cmd *cobra.Command, args []string) {
+	if len(conformanceEndpoints) == 0 {
+		conformanceEndpoints = []string{harmony.URL}
+	}
+	reports := make([]harmony.ConformanceReport, 0, len(conformanceEndpoints))
+	for _, endpoint := range conformanceEndpoints {
+		reports = append(reports, harmony.CheckConformance(endpoint))
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ENDPOINT\tSCORE\tFAILED")
+	for _, report := range reports {
+		failed := ""
+		for _, check := range report.Checks {
+			if !check.Pass {
+				failed += check.Name + " "
+			}
+		}
+		fmt.Fprintf(w, "%s\t%d/%d\t%s\n", report.URL, report.Passed, report.Total, failed)
+	}
+	w.Flush()
+
+	data, err := json.Marshal(reports)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile("conformance-result.json", data, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -1,57 +1,108 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"math/big"
 	"percybolmer/rpc-shard-testing/rpctester/contracts/devtoken"
 	"percybolmer/rpc-shard-testing/rpctester/crypto"
+	"percybolmer/rpc-shard-testing/rpctester/harmony"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/spf13/cobra"
 )
 
+var (
+	tokenName     string
+	tokenSymbol   string
+	tokenDecimals uint8
+	tokenSupply   int64
+	forceDeploy   bool
+)
+
 func init() {
 	rootCmd.AddCommand(deployCMD)
+
+	deployCMD.Flags().StringVar(&tokenName, "name", "DevToken", "The name of the token")
+	deployCMD.Flags().StringVar(&tokenSymbol, "symbol", "DEVT", "The symbol of the token")
+	deployCMD.Flags().Uint8Var(&tokenDecimals, "decimals", 18, "The amount of decimals the token uses")
+	deployCMD.Flags().Int64Var(&tokenSupply, "supply", 100000, "The total supply in whole tokens, it is multiplied by the decimals")
+	deployCMD.Flags().BoolVarP(&forceDeploy, "force", "f", false, "Deploy a new contract even if the configured SMART_CONTRACT_ADDRESS already holds the Devtoken")
 }
 
 var deployCMD = &cobra.Command{
 	Use:   "deploy",
 	Short: "Deploy will deploy needed smart contracts for the testing to the configure network and user in the .env file.",
-	Long:  ``,
-	Run:   deployContracts,
+	Long: `Deploy the Devtoken contract, wait for it to be included and verify the code using hmy_getCode.
+The address and creation hash are written back into the active .env profile.
+If the profile already points at a deployed Devtoken it is reused unless --force is set.`,
+	Run: deployContracts,
 }
 
 func deployContracts(cmd *cobra.Command, args []string) {
+	if !forceDeploy && harmony.SmartContractAddress != (common.Address{}) {
+		codeHash, err := harmony.VerifyDevtokenCode(harmony.SmartContractAddress)
+		if err == nil {
+			fmt.Println("Reusing contract addr: ", harmony.SmartContractAddress.Hex())
+			fmt.Println("Code hash: ", codeHash.Hex())
+			return
+		}
+		log.Printf("Not reusing %s: %v", harmony.SmartContractAddress.Hex(), err)
+	}
 
 	client, auth := crypto.NewClient()
 
-	address, tx, instance, err := devtoken.DeployDevtoken(auth, client, "DevToken", "DEVT", 18, big.NewInt(0).Mul(big.NewInt(1000000000000000000), big.NewInt(100000)))
+	supply := big.NewInt(0).Exp(big.NewInt(10), big.NewInt(int64(tokenDecimals)), nil)
+	supply.Mul(supply, big.NewInt(tokenSupply))
+
+	address, tx, _, err := devtoken.DeployDevtoken(auth, client, tokenName, tokenSymbol, tokenDecimals, supply)
 	if err != nil {
 		log.Fatal(err)
 	}
+	harmony.TrackSubmission(tx.Hash().Hex(), time.Now())
 
 	fmt.Println("Contract addr: ", address.Hex())
 	fmt.Println("Creation Hash: ", tx.Hash().Hex())
 
-	_ = instance
-
-	time.Sleep(5 * time.Second)
-
-	// load contract example
-	instance, err = devtoken.NewDevtoken(address, client)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	receipt, err := harmony.WaitForReceipt(ctx, tx.Hash().Hex())
 	if err != nil {
 		log.Fatal(err)
 	}
+	if receipt.Status != 1 {
+		log.Fatalf("Deployment %s failed with status %d", tx.Hash().Hex(), receipt.Status)
+	}
+	latency, _ := harmony.InclusionLatency(tx.Hash().Hex())
+	fmt.Println("Included after: ", latency)
 
-	data, err := instance.Name(&bind.CallOpts{})
+	codeHash, err := harmony.VerifyDevtokenCode(address)
 	if err != nil {
 		log.Fatal(err)
 	}
+	fmt.Println("Code hash: ", codeHash.Hex())
 
-	log.Println("name is: ")
-	log.Print(data)
+	instance, err := devtoken.NewDevtoken(address, client)
+	if err != nil {
+		log.Fatal(err)
+	}
+	name, err := instance.Name(&bind.CallOpts{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if name != tokenName {
+		log.Fatalf("Deployed token is named %q, expected %q", name, tokenName)
+	}
 
+	if err := harmony.UpdateProfile(map[string]string{
+		"SMART_CONTRACT_ADDRESS":     address.Hex(),
+		"SMART_CONTRACT_DEPLOY_HASH": tx.Hash().Hex(),
+	}); err != nil {
+		log.Fatalf("Deployed, but failed to update %s: %v", harmony.ProfileFile(), err)
+	}
+	fmt.Println("Updated ", harmony.ProfileFile())
 }
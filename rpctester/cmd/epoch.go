@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"percybolmer/rpc-shard-testing/rpctester/harmony"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var epochCMD = &cobra.Command{
+	Use:   "epoch",
+	Short: "Watch the chain across an epoch boundary and check the committee transition",
+	Long: `Poll hmy_latestHeader until the current epoch ends and find its last block from the epoch of the headers.
+hmy_epochLastBlock and hmy_isLastBlock are checked against that block, getSuperCommittees has to report the old committees as previous,
+getBlockSigners of the last and first block has to match the committee of their epoch and getElectedValidatorAddresses the external validators.
+Only the beacon shard serves these methods, on mainnet an epoch lasts about 18 hours.`,
+	Run: watchEpoch,
+}
+
+var (
+	epochTimeout  int
+	epochInterval int
+)
+
+// EpochResult is the outcome of the epoch command
+type EpochResult struct {
+	Network   string               `json:"network"`
+	Epoch     int64                `json:"epoch"`
+	LastBlock int64                `json:"lastBlock"`
+	Elected   []string             `json:"elected"`
+	Unelected []string             `json:"unelected"`
+	Joined    []string             `json:"joinedKeys"`
+	Left      []string             `json:"leftKeys"`
+	Checks    []harmony.EpochCheck `json:"checks"`
+}
+
+func init() {
+	rootCmd.AddCommand(epochCMD)
+
+	epochCMD.Flags().IntVarP(&epochTimeout, "timeout", "t", 0, "Minutes to wait for the epoch to end, 0 waits until it does")
+	epochCMD.Flags().IntVarP(&epochInterval, "interval", "i", 2, "Seconds between every poll of the latest header")
+}
+
+func watchEpoch(cmd *cobra.Command, args []string) {
+	if epochInterval <= 0 {
+		log.Fatal("--interval has to be at least 1 second")
+	}
+	header, err := harmony.GetLatestHeader()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if lastBlock, err := harmony.EpochLastBlock(header.Epoch); err == nil {
+		log.Printf("Waiting for epoch %d to end at block %d, the head is block %d", header.Epoch, lastBlock, header.BlockNumber)
+	}
+
+	ctx := context.Background()
+	if epochTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(epochTimeout)*time.Minute)
+		defer cancel()
+	}
+	transition, err := harmony.WatchEpochTransition(ctx, time.Duration(epochInterval)*time.Second)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	result := EpochResult{
+		Network:   harmony.URL,
+		Epoch:     transition.Epoch,
+		LastBlock: transition.LastBlock,
+		Checks:    harmony.CheckEpochTransition(transition),
+	}
+	result.Elected, result.Unelected = transition.ElectionChanges()
+	result.Joined, result.Left = transition.CommitteeChanges(transition.After.Header.ShardID)
+
+	printEpochResult(result)
+	data, err := json.Marshal(result)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile("epoch-result.json", data, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func printEpochResult(result EpochResult) {
+	log.Printf("Epoch %d ended at block %d", result.Epoch, result.LastBlock)
+	log.Printf("%d validators were elected and %d unelected, %d keys joined and %d left the committee", len(result.Elected), len(result.Unelected), len(result.Joined), len(result.Left))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHECK\tMETHOD\tRESULT")
+	for _, check := range result.Checks {
+		status := "ok"
+		if check.Error != "" {
+			status = check.Error
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", check.Name, check.Method, status)
+	}
+	w.Flush()
+}
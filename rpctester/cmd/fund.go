@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"percybolmer/rpc-shard-testing/rpctester/harmony"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var fundCMD = &cobra.Command{
+	Use:   "fund",
+	Short: "Fund will send ONE from the configured account to the accounts derived from the MNEMONIC",
+	Long:  ``,
+	Run:   fundWallets,
+}
+
+var (
+	walletCount int
+	fundAmount  float64
+)
+
+func init() {
+	rootCmd.AddCommand(fundCMD)
+
+	fundCMD.Flags().IntVarP(&walletCount, "accounts", "n", 10, "The amount of accounts to derive from the mnemonic and fund")
+	fundCMD.Flags().Float64VarP(&fundAmount, "amount", "a", 1, "The amount of ONE to send to every account")
+}
+
+func fundWallets(cmd *cobra.Command, args []string) {
+	pool, err := harmony.NewWalletPool(walletCount)
+	if err != nil {
+		log.Fatal(err)
+	}
+	amount, _ := big.NewFloat(0).Mul(big.NewFloat(fundAmount), big.NewFloat(0).SetInt(harmony.ONE)).Int(nil)
+	from := common.HexToAddress(harmony.TestAddress)
+
+	hashes := make([]string, 0, len(pool.Wallets()))
+	for _, wallet := range pool.Wallets() {
+		rlp, err := harmony.CreateRLPString(wallet.Address(), from, *amount, nil)
+		if err != nil {
+			log.Fatal(err)
+		}
+		hash, err := harmony.SendRawTransaction(rlp)
+		if err != nil {
+			log.Fatalf("Failed to fund %s: %v", wallet.Address().Hex(), err)
+		}
+		hashes = append(hashes, hash)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	for _, hash := range hashes {
+		if _, err := harmony.WaitForReceipt(ctx, hash); err != nil {
+			log.Fatalf("Failed waiting for %s: %v", hash, err)
+		}
+	}
+
+	for i, wallet := range pool.Wallets() {
+		var balance big.Int
+		if _, err := harmony.CallMethod(harmony.URL, methods.METHOD_V2_getBalance, []interface{}{wallet.Address().Hex()}, &balance); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("%d\t%s\t%s\t%s\n", i, wallet.Address().Hex(), harmony.ToBech32(wallet.Address()), balance.String())
+	}
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"percybolmer/rpc-shard-testing/rpctester/harmony"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var gasCMD = &cobra.Command{
+	Use:   "gas",
+	Short: "Analyze how accurate hmy_estimateGas and hmy_gasPrice are",
+	Long: `Estimate the gas of plain transfers and DevToken calls with hmy_estimateGas, send them with the configured GAS_LIMIT
+and compare the estimate with the gasUsed of the receipt. The ratio is estimated / used, below 1 the estimate would run out of gas.
+With --probe, self transfers are sent at different gas prices to find the lowest price the node accepts and compare it with hmy_gasPrice.
+Every accepted probe is a real transaction.`,
+	Run: analyzeGas,
+}
+
+var (
+	gasProbe     bool
+	gasMaxProbes int
+)
+
+// GasResult is the outcome of the gas command
+type GasResult struct {
+	Network   string                     `json:"network"`
+	Estimates []harmony.GasEstimate      `json:"estimates"`
+	Summary   harmony.GasEstimateSummary `json:"summary"`
+	GasPrice  *harmony.GasPriceReport    `json:"gasPrice,omitempty"`
+	Error     string                     `json:"error,omitempty"`
+}
+
+func init() {
+	rootCmd.AddCommand(gasCMD)
+
+	gasCMD.Flags().BoolVarP(&gasProbe, "probe", "p", false, "Probe the lowest accepted gas price by sending transactions")
+	gasCMD.Flags().IntVarP(&gasMaxProbes, "max-probes", "n", 40, "The most transactions to send when probing the gas price")
+}
+
+func analyzeGas(cmd *cobra.Command, args []string) {
+	cases, err := harmony.GasCases()
+	if err != nil {
+		log.Fatal(err)
+	}
+	result := GasResult{Network: harmony.URL}
+	for _, gasCase := range cases {
+		estimate := harmony.MeasureGasEstimate(gasCase)
+		if estimate.Error != "" {
+			log.Printf("%s: %s", estimate.Name, estimate.Error)
+		}
+		result.Estimates = append(result.Estimates, estimate)
+	}
+	result.Summary = harmony.SummarizeGasEstimates(result.Estimates)
+
+	if gasProbe {
+		report, err := harmony.ProbeGasPrice(gasMaxProbes)
+		if err != nil {
+			log.Printf("Probing the gas price failed: %v", err)
+			result.Error = err.Error()
+		}
+		result.GasPrice = report
+	}
+
+	printGasResult(result)
+	data, err := json.Marshal(result)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile("gas-result.json", data, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func printGasResult(result GasResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CALL\tESTIMATED\tUSED\tRATIO\tNOTE")
+	for _, estimate := range result.Estimates {
+		note := estimate.Error
+		if estimate.UnderEstimated() {
+			note = "underestimated"
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.3f\t%s\n", estimate.Name, estimate.Estimated, estimate.Used, estimate.Ratio, note)
+	}
+	w.Flush()
+
+	s := result.Summary
+	log.Printf("%d calls measured, %d underestimated, ratio min %.3f max %.3f mean %.3f", s.Measured, s.UnderEstimated, s.MinRatio, s.MaxRatio, s.MeanRatio)
+	if p := result.GasPrice; p != nil && p.MinimumAccepted != nil {
+		log.Printf("hmy_gasPrice suggests %s, the lowest accepted price is %s, %.2f times lower, found with %d probes", p.Suggested, p.MinimumAccepted, p.Margin, p.Probes)
+		if !p.SuggestedAccepted {
+			log.Printf("The suggested gas price %s was rejected", p.Suggested)
+		}
+		if !p.Mined {
+			log.Printf("The probe at the lowest accepted price was not mined within a minute")
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"percybolmer/rpc-shard-testing/rpctester/harmony"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var methodsCMD = &cobra.Command{
+	Use:   "methods",
+	Short: "List the RPC methods in the registry with their metadata",
+	Long: `Print every method in the methods registry with its namespace, version, category, params, result type
+and V1/V2 counterpart. Use --markdown to generate the docs table, or --coverage with a results.json
+from the sanity tests to list the methods that no test covers.`,
+	Run: listMethods,
+}
+
+var (
+	methodsMarkdown bool
+	methodsCoverage string
+)
+
+func init() {
+	rootCmd.AddCommand(methodsCMD)
+
+	methodsCMD.Flags().BoolVarP(&methodsMarkdown, "markdown", "m", false, "Print the registry as a markdown table")
+	methodsCMD.Flags().StringVarP(&methodsCoverage, "coverage", "c", "", "Path to a results.json, lists the methods without tests")
+}
+
+func listMethods(cmd *cobra.Command, args []string) {
+	if methodsCoverage != "" {
+		printCoverage(methodsCoverage)
+		return
+	}
+	if methodsMarkdown {
+		fmt.Println("| Method | Category | Params | Result | Counterpart | Notes |")
+		fmt.Println("|---|---|---|---|---|---|")
+		for _, m := range methods.Registered() {
+			fmt.Printf("| `%s` | %s | %s | `%s` | %s | %s |\n", m.Name, m.Category, formatParams(m.Params), m.Result, m.Counterpart, methodNotes(m))
+		}
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "METHOD\tNAMESPACE\tVERSION\tCATEGORY\tPARAMS\tRESULT\tCOUNTERPART\tNOTES")
+	for _, m := range methods.Registered() {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t%s\t%s\n", m.Name, m.Namespace, m.Version, m.Category, formatParams(m.Params), m.Result, m.Counterpart, methodNotes(m))
+	}
+	w.Flush()
+}
+
+// printCoverage lists the registered methods that have no metric in the results file
+func printCoverage(path string) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var results harmony.TestResults
+	if err := json.Unmarshal(data, &results); err != nil {
+		log.Fatal(err)
+	}
+	uncovered := harmony.UncoveredMethods(results.Metrics)
+	for _, name := range uncovered {
+		fmt.Println(name)
+	}
+	covered := len(methods.Registry) - len(uncovered)
+	log.Printf("%d of %d methods are covered by tests", covered, len(methods.Registry))
+}
+
+func formatParams(params []methods.Param) string {
+	names := make([]string, 0, len(params))
+	for _, p := range params {
+		name := fmt.Sprintf("%s:%s", p.Name, p.Type)
+		if p.GoType != "" {
+			name = fmt.Sprintf("%s:%s", p.Name, p.GoType)
+		}
+		if p.Optional {
+			name += "?"
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func methodNotes(m methods.Method) string {
+	notes := []string{}
+	if m.Mutates {
+		notes = append(notes, "mutates")
+	}
+	if m.Archive {
+		notes = append(notes, "archive")
+	}
+	return strings.Join(notes, ", ")
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"percybolmer/rpc-shard-testing/rpctester/harmony"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var monitorCMD = &cobra.Command{
+	Use:   "monitor",
+	Short: "Monitor how far a set of RPC nodes for the same shard lags behind the most advanced one",
+	Long: `Poll hmy_latestHeader and hmy_syncing on every endpoint and compare the block height and timestamp
+with the most advanced node. Nodes that are unreachable, syncing or lag too much are flagged as stale.`,
+	Run: monitorNodes,
+}
+
+var (
+	monitorEndpoints   []string
+	monitorInterval    int
+	monitorPolls       int
+	monitorMaxBlockLag int64
+	monitorMaxTimeLag  int
+	monitorJSON        bool
+)
+
+func init() {
+	rootCmd.AddCommand(monitorCMD)
+
+	monitorCMD.Flags().StringSliceVarP(&monitorEndpoints, "endpoints", "e", nil, "Comma separated RPC endpoints for the same shard, defaults to NET_URL")
+	monitorCMD.Flags().IntVarP(&monitorInterval, "interval", "i", 5, "Seconds between every poll")
+	monitorCMD.Flags().IntVarP(&monitorPolls, "polls", "n", 0, "The amount of polls to do, 0 polls until stopped")
+	monitorCMD.Flags().Int64VarP(&monitorMaxBlockLag, "max-block-lag", "b", 5, "Blocks a node can be behind before it is stale")
+	monitorCMD.Flags().IntVarP(&monitorMaxTimeLag, "max-time-lag", "s", 30, "Seconds the head of a node can be behind before it is stale")
+	monitorCMD.Flags().BoolVarP(&monitorJSON, "json", "j", false, "Print every poll as a line of JSON instead of a table")
+}
+
+func monitorNodes(cmd *cobra.Command, args []string) {
+	if monitorInterval <= 0 {
+		log.Fatal("--interval has to be at least 1 second")
+	}
+	if len(monitorEndpoints) == 0 {
+		monitorEndpoints = []string{harmony.URL}
+	}
+	ticker := time.NewTicker(time.Duration(monitorInterval) * time.Second)
+	defer ticker.Stop()
+
+	for poll := 1; ; poll++ {
+		statuses := pollNodes(monitorEndpoints)
+		harmony.CompareNodeStatuses(statuses, monitorMaxBlockLag, time.Duration(monitorMaxTimeLag)*time.Second)
+		if monitorJSON {
+			data, err := json.Marshal(statuses)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Println(string(data))
+		} else {
+			printNodeTable(statuses)
+		}
+		if monitorPolls > 0 && poll >= monitorPolls {
+			return
+		}
+		<-ticker.C
+	}
+}
+
+// pollNodes fetches the status of every endpoint concurrently so that they are compared at the same time
+func pollNodes(endpoints []string) []harmony.NodeStatus {
+	statuses := make([]harmony.NodeStatus, len(endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func(i int, endpoint string) {
+			defer wg.Done()
+			statuses[i] = harmony.GetNodeStatus(endpoint)
+		}(i, endpoint)
+	}
+	wg.Wait()
+	return statuses
+}
+
+func printNodeTable(statuses []harmony.NodeStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%s\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintln(w, "ENDPOINT\tSHARD\tBLOCK\tBLOCK LAG\tTIME LAG\tSYNCING\tLATENCY\tSTATUS")
+	for _, status := range statuses {
+		state := "ok"
+		if status.Stale {
+			state = "STALE: " + status.Reason
+		}
+		if status.Error != "" {
+			state += " (" + status.Error + ")"
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%ds\t%t\t%s\t%s\n", status.URL, status.ShardID, status.BlockNumber, status.BlockLag, status.TimeLag, status.Syncing, status.Latency, state)
+	}
+	w.Flush()
+}
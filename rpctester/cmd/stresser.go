@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -48,12 +49,13 @@ var (
 	/**
 	Global data shared acrross the stressers
 	*/
-	networkHeader            harmony.NetworkHeader
-	SelectedValidatorAddress string
-	TransactionHash          string
-	StakingTransactionHash   string
-	TransactionReceipt       harmony.TransactionReceipt_V2
-	TransactionReceiptV1     harmony.TransactionReceipt_V1
+	networkHeader             harmony.NetworkHeader
+	SelectedValidatorAddress  string
+	TransactionHash           string
+	StakingTransactionHash    string
+	CrossShardTransactionHash string
+	TransactionReceipt        harmony.TransactionReceipt_V2
+	TransactionReceiptV1      harmony.TransactionReceipt_V1
 )
 
 type Result struct {
@@ -100,6 +102,13 @@ func stressTest(cmd *cobra.Command, args []string) {
 	stressFilterMethods()
 	stressTransactionMethods()
 	stressTraceMethods()
+	stressEthMethods()
+	stressExplorerMethods()
+	for _, m := range methods.Registered() {
+		if _, ok := result.Methods[m.Name]; !ok {
+			log.Printf("%s was not benchmarked", m.Name)
+		}
+	}
 	// After all tests, Generate report
 	data, err := json.Marshal(result)
 	if err != nil {
@@ -118,6 +127,54 @@ func stressTraceMethods() {
 
 }
 
+// stressEthMethods benchmarks the eth, net and web3 namespaces using the transaction sent by stressTransactionMethods
+// eth_sendRawTransaction and eth_uninstallFilter only succeed once per transaction or filter and are left to the sanity tests
+func stressEthMethods() {
+	log.Println("Benchmarking Eth methods")
+	address := common.HexToAddress(harmony.TestAddress)
+	blockNumber := harmony.EthBlockNumber(TransactionReceipt.BlockNumber)
+	transactionIndex := hexutil.EncodeUint64(uint64(TransactionReceipt.TransactionIndex))
+	logFilter := harmony.LogFilter{FromBlock: blockNumber, ToBlock: blockNumber, Address: harmony.SmartContractAddress.Hex()}
+	rpcCall := harmony.RpcCallArgs{
+		From: address.Hex(),
+		To:   harmony.SmartContractAddress.Hex(),
+	}
+
+	benchmarkMethod(methods.METHOD_eth_chainId, BuildRequestGenerator(methods.METHOD_eth_chainId, []interface{}{}))
+	benchmarkMethod(methods.METHOD_eth_protocolVersion, BuildRequestGenerator(methods.METHOD_eth_protocolVersion, []interface{}{}))
+	benchmarkMethod(methods.METHOD_eth_syncing, BuildRequestGenerator(methods.METHOD_eth_syncing, []interface{}{}))
+	benchmarkMethod(methods.METHOD_eth_blockNumber, BuildRequestGenerator(methods.METHOD_eth_blockNumber, []interface{}{}))
+	benchmarkMethod(methods.METHOD_eth_gasPrice, BuildRequestGenerator(methods.METHOD_eth_gasPrice, []interface{}{}))
+	benchmarkMethod(methods.METHOD_eth_getBalance, BuildRequestGenerator(methods.METHOD_eth_getBalance, []interface{}{address, "latest"}))
+	benchmarkMethod(methods.METHOD_eth_getTransactionCount, BuildRequestGenerator(methods.METHOD_eth_getTransactionCount, []interface{}{address, "latest"}))
+	benchmarkMethod(methods.METHOD_eth_getCode, BuildRequestGenerator(methods.METHOD_eth_getCode, []interface{}{harmony.SmartContractAddress, "latest"}))
+	benchmarkMethod(methods.METHOD_eth_getStorageAt, BuildRequestGenerator(methods.METHOD_eth_getStorageAt, []interface{}{harmony.SmartContractAddress, "0x0", "latest"}))
+	benchmarkMethod(methods.METHOD_eth_call, BuildRequestGenerator(methods.METHOD_eth_call, []interface{}{rpcCall, "latest"}))
+	benchmarkMethod(methods.METHOD_eth_estimateGas, BuildRequestGenerator(methods.METHOD_eth_estimateGas, []interface{}{rpcCall}))
+	benchmarkMethod(methods.METHOD_eth_getBlockByNumber, BuildRequestGenerator(methods.METHOD_eth_getBlockByNumber, []interface{}{blockNumber, true}))
+	benchmarkMethod(methods.METHOD_eth_getBlockByHash, BuildRequestGenerator(methods.METHOD_eth_getBlockByHash, []interface{}{TransactionReceipt.BlockHash, true}))
+	benchmarkMethod(methods.METHOD_eth_getBlockTransactionCountByNumber, BuildRequestGenerator(methods.METHOD_eth_getBlockTransactionCountByNumber, []interface{}{blockNumber}))
+	benchmarkMethod(methods.METHOD_eth_getBlockTransactionCountByHash, BuildRequestGenerator(methods.METHOD_eth_getBlockTransactionCountByHash, []interface{}{TransactionReceipt.BlockHash}))
+	benchmarkMethod(methods.METHOD_eth_getTransactionByHash, BuildRequestGenerator(methods.METHOD_eth_getTransactionByHash, []interface{}{TransactionHash}))
+	benchmarkMethod(methods.METHOD_eth_getTransactionByBlockNumberAndIndex, BuildRequestGenerator(methods.METHOD_eth_getTransactionByBlockNumberAndIndex, []interface{}{blockNumber, transactionIndex}))
+	benchmarkMethod(methods.METHOD_eth_getTransactionByBlockHashAndIndex, BuildRequestGenerator(methods.METHOD_eth_getTransactionByBlockHashAndIndex, []interface{}{TransactionReceipt.BlockHash, transactionIndex}))
+	benchmarkMethod(methods.METHOD_eth_getTransactionReceipt, BuildRequestGenerator(methods.METHOD_eth_getTransactionReceipt, []interface{}{TransactionHash}))
+	benchmarkMethod(methods.METHOD_eth_getLogs, BuildRequestGenerator(methods.METHOD_eth_getLogs, []interface{}{logFilter}))
+	benchmarkMethod(methods.METHOD_eth_newFilter, BuildRequestGenerator(methods.METHOD_eth_newFilter, []interface{}{logFilter}))
+	var logFilterID string
+	if err := GetMethodResponse(methods.METHOD_eth_newFilter, &logFilterID); err != nil {
+		log.Println("Missing eth filter id ", err.Error())
+	}
+	benchmarkMethod(methods.METHOD_eth_getFilterLogs, BuildRequestGenerator(methods.METHOD_eth_getFilterLogs, []interface{}{logFilterID}))
+	benchmarkMethod(methods.METHOD_eth_getFilterChanges, BuildRequestGenerator(methods.METHOD_eth_getFilterChanges, []interface{}{logFilterID}))
+	benchmarkMethod(methods.METHOD_eth_newBlockFilter, BuildRequestGenerator(methods.METHOD_eth_newBlockFilter, []interface{}{}))
+	benchmarkMethod(methods.METHOD_eth_newPendingTransactionFilter, BuildRequestGenerator(methods.METHOD_eth_newPendingTransactionFilter, []interface{}{}))
+	benchmarkMethod(methods.METHOD_net_version, BuildRequestGenerator(methods.METHOD_net_version, []interface{}{}))
+	benchmarkMethod(methods.METHOD_net_listening, BuildRequestGenerator(methods.METHOD_net_listening, []interface{}{}))
+	benchmarkMethod(methods.METHOD_web3_clientVersion, BuildRequestGenerator(methods.METHOD_web3_clientVersion, []interface{}{}))
+	benchmarkMethod(methods.METHOD_web3_sha3, BuildRequestGenerator(methods.METHOD_web3_sha3, []interface{}{"0x68656c6c6f20776f726c64"}))
+}
+
 func stressTransactionMethods() {
 	log.Println("Benchmarking Transaction Methods")
 	benchmarkMethod(methods.METHOD_staking_V2_getElectedValidatorAddresses, BuildRequestGenerator(methods.METHOD_staking_V2_getElectedValidatorAddresses, []interface{}{}))
@@ -151,8 +208,9 @@ func stressTransactionMethods() {
 	benchmarkMethod(methods.METHOD_transaction_V2_getPendingCrossLinks, BuildRequestGenerator(methods.METHOD_transaction_V2_getPendingCrossLinks, []interface{}{}))
 	benchmarkMethod(methods.METHOD_transaction_V1_getPendingCXReceipts, BuildRequestGenerator(methods.METHOD_transaction_V1_getPendingCXReceipts, []interface{}{}))
 	benchmarkMethod(methods.METHOD_transaction_V2_getPendingCXReceipts, BuildRequestGenerator(methods.METHOD_transaction_V2_getPendingCXReceipts, []interface{}{}))
-	benchmarkMethod(methods.METHOD_transaction_V1_getCXReceiptByHash, BuildRequestGenerator(methods.METHOD_transaction_V1_getCXReceiptByHash, []interface{}{"0x6b106dc5619c86b6c0cb64b17e5c464e8008e08cf0f1bb0e3fa2657fb42daade"}))
-	benchmarkMethod(methods.METHOD_transaction_V2_getCXReceiptByHash, BuildRequestGenerator(methods.METHOD_transaction_V2_getCXReceiptByHash, []interface{}{"0x6b106dc5619c86b6c0cb64b17e5c464e8008e08cf0f1bb0e3fa2657fb42daade"}))
+	stressCrossShardSettlement()
+	benchmarkMethod(methods.METHOD_transaction_V1_getCXReceiptByHash, BuildRequestGenerator(methods.METHOD_transaction_V1_getCXReceiptByHash, []interface{}{CrossShardTransactionHash}))
+	benchmarkMethod(methods.METHOD_transaction_V2_getCXReceiptByHash, BuildRequestGenerator(methods.METHOD_transaction_V2_getCXReceiptByHash, []interface{}{CrossShardTransactionHash}))
 	benchmarkMethod(methods.METHOD_transaction_V1_pendingTransactions, BuildRequestGenerator(methods.METHOD_transaction_V1_pendingTransactions, []interface{}{}))
 	benchmarkMethod(methods.METHOD_transaction_V2_pendingTransactions, BuildRequestGenerator(methods.METHOD_transaction_V2_pendingTransactions, []interface{}{}))
 	benchmarkMethod(methods.METHOD_transaction_V1_getStakingTransactionByBlockHashAndIndex, BuildRequestGenerator(methods.METHOD_transaction_V1_getStakingTransactionByBlockHashAndIndex, []interface{}{networkHeader.BlockHash, "0x0"}))
@@ -213,6 +271,31 @@ func stressTransactionMethods() {
 
 }
 
+// stressCrossShardSettlement sends a single cross shard transaction and reports the time it takes to settle
+func stressCrossShardSettlement() {
+	toShardID, err := harmony.CrossShardDestination()
+	if err != nil {
+		log.Println("Failed to select cross shard destination", err.Error())
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	// Transfer 0.001 ONE
+	crossShard, err := harmony.SendCrossShardTransaction(ctx, toShardID, big.NewInt(0).Div(harmony.ONE, big.NewInt(1000)))
+	if crossShard != nil {
+		CrossShardTransactionHash = crossShard.TransactionHash
+	}
+	if err != nil {
+		log.Println("Failed cross shard transaction", err.Error())
+		result.Methods[harmony.METRIC_crossShardSettlement] = MethodResult{Responses: 1, Failures: 1}
+		return
+	}
+	result.Methods[harmony.METRIC_crossShardSettlement] = MethodResult{
+		Average:   crossShard.SettlementLatency.String(),
+		Responses: 1,
+	}
+}
+
 func stressFilterMethods() {
 	log.Println("Benchmarking Filter methods")
 	benchmarkMethod(methods.METHOD_filter_newFilter, BuildRequestGenerator(methods.METHOD_filter_newFilter, []interface{}{harmony.Filter{FromBlock: "0x1", ToBlock: "0x2", Address: harmony.TestAddress, Topics: []string{"0x000000000000000000000000a94f5374fce5edbc8e2a8697c15331677e6ebf0b"}}}))
@@ -240,6 +323,18 @@ func stressAccountMethods() {
 
 }
 
+func stressExplorerMethods() {
+	if harmony.ExplorerURL == "" {
+		log.Println("Skipping Explorer Methods, EXPLORER_URL is not set")
+		return
+	}
+	log.Println("Benchmarking Explorer Methods")
+	id := harmony.ToBech32(harmony.Parse(harmony.TestAddress))
+	benchmarkMethod(methods.METHOD_address, BuildGETRequestGenerator(func() (*http.Request, error) {
+		return harmony.NewAddressRequest(id, 10, 0, harmony.TxViewAll)
+	}))
+}
+
 func stressContractMethods() {
 	log.Println("Benchmarking Contract Methods")
 	benchmarkMethod(methods.METHOD_contract_getStorageAt, BuildRequestGenerator(methods.METHOD_contract_getStorageAt, []interface{}{harmony.SmartContractAddress, "0x0", "latest"}))
@@ -367,6 +462,18 @@ func BuildRequestGenerator(method string, params []interface{}) benchmarker.Gene
 	}
 }
 
+// BuildGETRequestGenerator is the same as BuildRequestGenerator but for GET endpoints such as the explorer API
+func BuildGETRequestGenerator(newRequest func() (*http.Request, error)) benchmarker.GenerateRequestFunc {
+	return func() *http.Request {
+		req, err := newRequest()
+		if err != nil {
+			log.Fatal("Your request constructer is broken")
+		}
+		log.Println(req.URL.String())
+		return req
+	}
+}
+
 // benchmarkMethod is general wrapper around calling an benchmark
 func benchmarkMethod(method string, requestGen benchmarker.GenerateRequestFunc) {
 	bencher := benchmarker.NewBenchmarker(requestsToSend, concurrent)
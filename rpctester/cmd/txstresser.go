@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"net/http"
+	"os"
+	"percybolmer/rpc-shard-testing/rpctester/benchmarker"
+	"percybolmer/rpc-shard-testing/rpctester/crypto"
+	"percybolmer/rpc-shard-testing/rpctester/harmony"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var txStressCMD = &cobra.Command{
+	Use:   "stress-tx",
+	Short: "Stress the transaction pool by sending unique signed transactions at a fixed rate",
+	Long:  ``,
+	Run:   stressTransactionPool,
+}
+
+var (
+	transactionsPerSecond int
+	transactionsToSend    int
+	txConcurrent          int
+	txAccounts            int
+)
+
+// TxStressResult is the outcome of stressing the transaction pool
+type TxStressResult struct {
+	AddressUsed string `json:"addressUsed"`
+	Network     string `json:"network"`
+	Rate        int    `json:"rate"`
+	Average     string
+	Sent        int64
+	Accepted    int64
+	Rejected    int64
+	// Errors counts the rejections by the error message returned
+	Errors map[string]int64
+	// NonceGaps are the accounts where the pool is waiting for a nonce lower than the ones sent after the run
+	NonceGaps []NonceGap
+}
+
+// NonceGap is the nonce that the pool is waiting for on an account
+type NonceGap struct {
+	Address      string
+	PendingNonce uint64
+}
+
+func init() {
+	rootCmd.AddCommand(txStressCMD)
+
+	txStressCMD.Flags().IntVarP(&transactionsPerSecond, "tps", "t", 10, "The amount of unique transactions to send every second")
+	txStressCMD.Flags().IntVarP(&transactionsToSend, "requests", "r", 100, "The total amount of transactions to send")
+	txStressCMD.Flags().IntVarP(&txConcurrent, "concurrent", "c", 10, "The concurrent amount of requests to send")
+	txStressCMD.Flags().IntVarP(&txAccounts, "accounts", "a", 0, "Send round robin from this amount of accounts derived from the MNEMONIC, 0 uses the configured account")
+}
+
+func stressTransactionPool(cmd *cobra.Command, args []string) {
+	runtime.GOMAXPROCS(runtime.NumCPU())
+
+	pool, err := stressWalletPool()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := resyncWallets(pool); err != nil {
+		log.Fatal(err)
+	}
+
+	txResult := TxStressResult{
+		AddressUsed: harmony.TestAddress,
+		Network:     harmony.URL,
+		Rate:        transactionsPerSecond,
+		Errors:      make(map[string]int64),
+	}
+
+	bencher := benchmarker.NewRateLimitedBenchmarker(transactionsToSend, txConcurrent, transactionsPerSecond)
+	reqChan := make(chan *http.Request)
+	respChan := make(chan benchmarker.Response)
+
+	sent := newSentNonces()
+	go bencher.Dispatcher(reqChan, BuildTransactionGenerator(pool, big.NewInt(1), sent))
+	go bencher.WorkerPool(reqChan, respChan)
+
+	var totalDuration int64
+	for txResult.Sent < int64(transactionsToSend) {
+		r := <-respChan
+		txResult.Sent++
+		totalDuration += r.Metric.Duration
+
+		errMsg := ""
+		var nonce sentNonce
+		var known bool
+		if r.Err != nil {
+			errMsg = r.Err.Error()
+		} else {
+			var baseresp harmony.BaseResponse
+			if err := json.Unmarshal(r.Return, &baseresp); err != nil {
+				errMsg = err.Error()
+			} else {
+				nonce, known = sent.take(baseresp.ID)
+				if baseresp.Error != nil {
+					errMsg = baseresp.Error.Message
+				}
+			}
+		}
+		if errMsg == "" {
+			txResult.Accepted++
+			continue
+		}
+		txResult.Rejected++
+		txResult.Errors[errMsg]++
+		// A rejected transaction leaves a gap in the nonces, hand its nonce out again
+		// Resyncing instead would reuse the nonces of the transactions that are still in flight
+		// A nonce that was too low is already used and is not handed out again
+		if known && !strings.Contains(errMsg, harmony.RejectNonceTooLow) {
+			harmony.GetNonceManager(nonce.address).Release(nonce.nonce)
+		}
+	}
+	if txResult.Sent > 0 {
+		txResult.Average = time.Duration(totalDuration / txResult.Sent).String()
+	}
+
+	for _, wallet := range pool.Wallets() {
+		pending, gap, err := harmony.GetNonceManager(wallet.Address()).DetectGap()
+		if err != nil {
+			log.Println("Failed to detect nonce gaps", err.Error())
+			continue
+		}
+		if gap {
+			log.Printf("Nonce gap detected, the pool is waiting for nonce %d from %s", pending, wallet.Address().Hex())
+			txResult.NonceGaps = append(txResult.NonceGaps, NonceGap{Address: wallet.Address().Hex(), PendingNonce: pending})
+		}
+	}
+
+	data, err := json.Marshal(txResult)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = ioutil.WriteFile("stress-tx-result.json", data, os.ModePerm)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// stressWalletPool returns the accounts to send transactions from
+func stressWalletPool() (*harmony.WalletPool, error) {
+	if txAccounts > 0 {
+		return harmony.NewWalletPool(txAccounts)
+	}
+	return harmony.NewWalletPoolFromWallets(crypto.GetSigner()), nil
+}
+
+// resyncWallets fetches the pending nonce of all wallets from the network
+func resyncWallets(pool *harmony.WalletPool) error {
+	for _, wallet := range pool.Wallets() {
+		if err := harmony.GetNonceManager(wallet.Address()).Resync(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sentNonce is the account and nonce of a transaction that has been sent
+type sentNonce struct {
+	address common.Address
+	nonce   uint64
+}
+
+// sentNonces remembers the nonce of every transaction by the id of its request
+// The response echoes the id, so that the nonce of a rejected transaction can be released
+type sentNonces struct {
+	sync.Mutex
+	lastID int64
+	byID   map[string]sentNonce
+}
+
+func newSentNonces() *sentNonces {
+	return &sentNonces{byID: make(map[string]sentNonce)}
+}
+
+// add stores the nonce and returns the id to send the request with
+func (s *sentNonces) add(address common.Address, nonce uint64) string {
+	s.Lock()
+	defer s.Unlock()
+	s.lastID++
+	id := strconv.FormatInt(s.lastID, 10)
+	s.byID[id] = sentNonce{address: address, nonce: nonce}
+	return id
+}
+
+// take returns and forgets the nonce sent with the raw id of a response
+func (s *sentNonces) take(rawID json.RawMessage) (sentNonce, bool) {
+	var id string
+	if err := json.Unmarshal(rawID, &id); err != nil {
+		return sentNonce{}, false
+	}
+	s.Lock()
+	defer s.Unlock()
+	nonce, ok := s.byID[id]
+	delete(s.byID, id)
+	return nonce, ok
+}
+
+// BuildTransactionGenerator generates requests that each contain a new signed transaction
+// The wallets in the pool take turns sending amount to themself, the nonce of every request is stored in sent
+func BuildTransactionGenerator(pool *harmony.WalletPool, amount *big.Int, sent *sentNonces) benchmarker.GenerateRequestFunc {
+	return func() *http.Request {
+		shardID, err := crypto.GetShardID()
+		if err != nil {
+			log.Fatal(err)
+		}
+		wallet := pool.Next()
+		rlp, nonce, err := harmony.CreateSignedTransfer(wallet, wallet.Address(), *amount, nil, uint32(shardID))
+		if err != nil {
+			log.Fatal("Failed to create RLP string for transaction stressing: ", err)
+		}
+		payload, err := json.Marshal(BaseRequest{
+			ID:      sent.add(wallet.Address(), nonce),
+			JsonRPC: "2.0",
+			Method:  methods.METHOD_transaction_sendRawTransaction,
+			Params:  []interface{}{rlp},
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		req, err := http.NewRequest(http.MethodPost, harmony.URL, bytes.NewBuffer(payload))
+		if err != nil {
+			log.Fatal("Your request constructer is broken")
+		}
+		req.Header.Add("content-type", "application/json")
+		return req
+	}
+}
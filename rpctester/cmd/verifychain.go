@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"percybolmer/rpc-shard-testing/rpctester/harmony"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var verifyChainCMD = &cobra.Command{
+	Use:   "verify-chain",
+	Short: "Verify that the blocks, transactions and receipts served by the RPC link up",
+	Long: `Walk the blocks from --from to --to using hmyv2_getBlocks and check that
+parent hashes link, timestamps never go backwards, the transaction count matches the block,
+and every transaction and receipt can be fetched and points back at its block.`,
+	Run: verifyChain,
+}
+
+var (
+	verifyFrom     int64
+	verifyTo       int64
+	verifyPageSize int64
+)
+
+// ChainIssue is an inconsistency found in a block
+type ChainIssue struct {
+	Block       int64  `json:"block"`
+	Transaction string `json:"transaction,omitempty"`
+	Issue       string `json:"issue"`
+}
+
+// ChainVerificationResult is the outcome of verify-chain
+type ChainVerificationResult struct {
+	Network      string       `json:"network"`
+	From         int64        `json:"from"`
+	To           int64        `json:"to"`
+	Blocks       int64        `json:"blocks"`
+	Transactions int64        `json:"transactions"`
+	Issues       []ChainIssue `json:"issues"`
+}
+
+func init() {
+	rootCmd.AddCommand(verifyChainCMD)
+
+	verifyChainCMD.Flags().Int64VarP(&verifyFrom, "from", "f", 1, "The first block to verify")
+	verifyChainCMD.Flags().Int64VarP(&verifyTo, "to", "t", 0, "The last block to verify, 0 is the latest block")
+	verifyChainCMD.Flags().Int64VarP(&verifyPageSize, "page", "p", 50, "The amount of blocks to fetch with every hmyv2_getBlocks request")
+}
+
+func verifyChain(cmd *cobra.Command, args []string) {
+	if verifyTo == 0 {
+		latest, err := harmony.BlockNumber()
+		if err != nil {
+			log.Fatal(err)
+		}
+		verifyTo = latest
+	}
+	if verifyFrom < 0 || verifyFrom > verifyTo || verifyPageSize <= 0 {
+		log.Fatalf("Invalid range %d to %d", verifyFrom, verifyTo)
+	}
+
+	result := ChainVerificationResult{
+		Network: harmony.URL,
+		From:    verifyFrom,
+		To:      verifyTo,
+		Issues:  []ChainIssue{},
+	}
+
+	var previous *harmony.BlockV2
+	if verifyFrom > 0 {
+		parent, err := harmony.GetBlockByNumber(verifyFrom-1, harmony.BlockArgs{})
+		if err != nil {
+			log.Fatal(err)
+		}
+		previous = parent
+	}
+
+	for start := verifyFrom; start <= verifyTo; start += verifyPageSize {
+		end := start + verifyPageSize - 1
+		if end > verifyTo {
+			end = verifyTo
+		}
+		blocks, err := harmony.GetBlocks(start, end, harmony.BlockArgs{FullTx: true})
+		if err != nil {
+			log.Fatal(err)
+		}
+		if int64(len(blocks)) != end-start+1 {
+			result.Issues = append(result.Issues, ChainIssue{Block: start, Issue: fmt.Sprintf("getBlocks returned %d blocks for %d to %d", len(blocks), start, end)})
+		}
+		for i := range blocks {
+			block := &blocks[i]
+			result.Issues = append(result.Issues, verifyBlock(block, previous)...)
+			result.Blocks++
+			result.Transactions += int64(len(block.Transaction))
+			previous = block
+		}
+		log.Printf("Verified blocks %d to %d, %d issues found", start, end, len(result.Issues))
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile("verify-chain-result.json", data, 0644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Verified %d blocks and %d transactions, %d issues found", result.Blocks, result.Transactions, len(result.Issues))
+}
+
+// verifyBlock checks a block against its parent and the transaction endpoints
+func verifyBlock(block *harmony.BlockV2, previous *harmony.BlockV2) []ChainIssue {
+	var issues []ChainIssue
+	report := func(tx string, format string, args ...interface{}) {
+		issues = append(issues, ChainIssue{Block: block.Number, Transaction: tx, Issue: fmt.Sprintf(format, args...)})
+	}
+
+	if previous != nil {
+		if block.Number != previous.Number+1 {
+			report("", "follows block %d", previous.Number)
+		}
+		if !strings.EqualFold(block.ParentHash, previous.Hash) {
+			report("", "parent hash %s does not match block %d hash %s", block.ParentHash, previous.Number, previous.Hash)
+		}
+		if block.Timestamp < previous.Timestamp {
+			report("", "timestamp %d is before the parent timestamp %d", block.Timestamp, previous.Timestamp)
+		}
+	}
+
+	count, err := harmony.GetBlockTransactionCountByNumber(block.Number)
+	if err != nil {
+		report("", "getBlockTransactionCountByNumber: %v", err)
+	} else if count != int64(len(block.Transaction)) {
+		report("", "getBlockTransactionCountByNumber returned %d, the block has %d transactions", count, len(block.Transaction))
+	}
+
+	for index, blockTx := range block.Transaction {
+		hash := blockTx.Hash
+
+		byHash, err := harmony.GetTransactionByHash(hash)
+		if err != nil {
+			report(hash, "getTransactionByHash: %v", err)
+		} else if !strings.EqualFold(byHash.BlockHash, block.Hash) || byHash.BlockNumber.Int64() != block.Number || byHash.TransactionIndex.Int64() != int64(index) {
+			report(hash, "getTransactionByHash places it in block %s (%d) at index %d, expected %s (%d) at %d",
+				byHash.BlockHash, byHash.BlockNumber.Int64(), byHash.TransactionIndex.Int64(), block.Hash, block.Number, index)
+		}
+
+		byIndex, err := harmony.GetTransactionByBlockNumberAndIndex(block.Number, index)
+		if err != nil {
+			report(hash, "getTransactionByBlockNumberAndIndex: %v", err)
+		} else if !strings.EqualFold(byIndex.Hash, hash) {
+			report(hash, "getTransactionByBlockNumberAndIndex returned %s at index %d", byIndex.Hash, index)
+		}
+
+		receipt, err := harmony.GetTransactionReceipt(hash)
+		if err != nil {
+			report(hash, "getTransactionReceipt: %v", err)
+		} else if !strings.EqualFold(receipt.TransactionHash, hash) || !strings.EqualFold(receipt.BlockHash, block.Hash) ||
+			receipt.BlockNumber != block.Number || receipt.TransactionIndex != index {
+			report(hash, "receipt of %s places it in block %s (%d) at index %d, expected %s (%d) at %d",
+				receipt.TransactionHash, receipt.BlockHash, receipt.BlockNumber, receipt.TransactionIndex, block.Hash, block.Number, index)
+		}
+	}
+	return issues
+}
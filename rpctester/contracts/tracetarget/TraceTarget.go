@@ -0,0 +1,111 @@
+// Package tracetarget holds a hand assembled contract used to test the trace endpoints
+// There is no Solidity source, the bytecode is small enough to follow opcode by opcode
+package tracetarget
+
+const (
+	// TraceTargetBin is the creation code, it copies the 129 byte runtime code into memory and returns it
+	TraceTargetBin = "0x608180600b6000396000f360003560f81c80600114602c57806002146040578060031460585780600414606357602a60005260206000f35b60206000600060006000305af15060206000f35b63600080f36000526004601c6000f060005260206000f35b602a60005260206000fd5b600360f81b60005260006000600160006000305af160005260206000f3"
+
+	// SelectorLeaf returns LeafOutput, it is also what empty calldata does
+	SelectorLeaf byte = 0x00
+	// SelectorNestedCall calls the contract itself with empty calldata and returns the result of that call
+	SelectorNestedCall byte = 0x01
+	// SelectorCreate deploys an empty contract with CREATE and returns its address
+	SelectorCreate byte = 0x02
+	// SelectorRevert reverts with LeafOutput as revert data
+	SelectorRevert byte = 0x03
+	// SelectorNestedRevert calls the contract itself with SelectorRevert and returns the success flag of that call, which is 0
+	SelectorNestedRevert byte = 0x04
+
+	// LeafOutput is the 32 byte word returned by SelectorLeaf and used as revert data by SelectorRevert
+	LeafOutput = "0x000000000000000000000000000000000000000000000000000000000000002a"
+)
+
+// The runtime code, the first byte of calldata selects what to do
+//
+//		00 PUSH1 0x00
+//		02 CALLDATALOAD
+//		03 PUSH1 0xf8
+//		05 SHR
+//		06 DUP1
+//		07 PUSH1 0x01
+//		09 EQ
+//		0a PUSH1 0x2c
+//		0c JUMPI
+//		0d DUP1
+//		0e PUSH1 0x02
+//		10 EQ
+//		11 PUSH1 0x40
+//		13 JUMPI
+//		14 DUP1
+//		15 PUSH1 0x03
+//		17 EQ
+//		18 PUSH1 0x58
+//		1a JUMPI
+//		1b DUP1
+//		1c PUSH1 0x04
+//		1e EQ
+//		1f PUSH1 0x63
+//		21 JUMPI
+//		22 PUSH1 0x2a
+//		24 PUSH1 0x00
+//		26 MSTORE
+//		27 PUSH1 0x20
+//		29 PUSH1 0x00
+//		2b RETURN
+//	nested:
+//		2c JUMPDEST
+//		2d PUSH1 0x20
+//		2f PUSH1 0x00
+//		31 PUSH1 0x00
+//		33 PUSH1 0x00
+//		35 PUSH1 0x00
+//		37 ADDRESS
+//		38 GAS
+//		39 CALL
+//		3a POP
+//		3b PUSH1 0x20
+//		3d PUSH1 0x00
+//		3f RETURN
+//	create:
+//		40 JUMPDEST
+//		41 PUSH4 0x600080f3
+//		46 PUSH1 0x00
+//		48 MSTORE
+//		49 PUSH1 0x04
+//		4b PUSH1 0x1c
+//		4d PUSH1 0x00
+//		4f CREATE
+//		50 PUSH1 0x00
+//		52 MSTORE
+//		53 PUSH1 0x20
+//		55 PUSH1 0x00
+//		57 RETURN
+//	revert:
+//		58 JUMPDEST
+//		59 PUSH1 0x2a
+//		5b PUSH1 0x00
+//		5d MSTORE
+//		5e PUSH1 0x20
+//		60 PUSH1 0x00
+//		62 REVERT
+//	nestedrevert:
+//		63 JUMPDEST
+//		64 PUSH1 0x03
+//		66 PUSH1 0xf8
+//		68 SHL
+//		69 PUSH1 0x00
+//		6b MSTORE
+//		6c PUSH1 0x00
+//		6e PUSH1 0x00
+//		70 PUSH1 0x01
+//		72 PUSH1 0x00
+//		74 PUSH1 0x00
+//		76 ADDRESS
+//		77 GAS
+//		78 CALL
+//		79 PUSH1 0x00
+//		7b MSTORE
+//		7c PUSH1 0x20
+//		7e PUSH1 0x00
+//		80 RETURN
@@ -3,6 +3,7 @@ package crypto
 import (
 	"context"
 	"crypto/ecdsa"
+	"fmt"
 	"log"
 	"math/big"
 	"os"
@@ -32,16 +33,12 @@ func NewClient() (*ethclient.Client, *bind.TransactOpts) {
 		log.Fatal(err)
 	}
 
-	chainID := os.Getenv("CHAIN_ID")
-	chain, err := strconv.Atoi(chainID)
+	chain, err := GetChainID()
 	if err != nil {
-		chain = 2
+		chain = big.NewInt(2)
 	}
 
-	auth, err := bind.NewKeyedTransactorWithChainID(GetPrivateKey(), big.NewInt(int64(chain)))
-	if err != nil {
-		log.Fatal(err)
-	}
+	auth := NewTransactor(GetSigner(), chain)
 
 	gasLimit := os.Getenv("GAS_LIMIT")
 	gasL, err := strconv.Atoi(gasLimit)
@@ -58,6 +55,7 @@ func NewClient() (*ethclient.Client, *bind.TransactOpts) {
 }
 
 // GetPrivateKey gets the private key from env
+// Prefer GetSigner, which also supports keystores and external signers
 func GetPrivateKey() *ecdsa.PrivateKey {
 	privateKey, err := crypto.HexToECDSA(os.Getenv("PRIVATE_KEY"))
 	if err != nil {
@@ -66,21 +64,21 @@ func GetPrivateKey() *ecdsa.PrivateKey {
 	return privateKey
 }
 
-// GetAddress is used to fetch a common.Address from env
+// GetAddress is used to fetch the common.Address of the configured signer
 func GetAddress() common.Address {
-	privateKey := GetPrivateKey()
-
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		log.Fatal("cannot assert type: publicKey is not of type *ecdsa.PublicKey")
-	}
-
-	fromAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
-	return fromAddress
+	return GetSigner().Address()
 }
 
 // GetShardID retruns the configured shardID to use
 func GetShardID() (int, error) {
 	return strconv.Atoi(os.Getenv("SHARD_ID"))
 }
+
+// GetChainID is the Ethereum chain ID from the CHAIN_ID env, as returned by eth_chainId
+func GetChainID() (*big.Int, error) {
+	chainID, ok := new(big.Int).SetString(os.Getenv("CHAIN_ID"), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid CHAIN_ID %q", os.Getenv("CHAIN_ID"))
+	}
+	return chainID, nil
+}
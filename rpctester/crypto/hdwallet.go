@@ -0,0 +1,126 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// HarmonyCoinType is the registered BIP-44 coin type for Harmony
+	HarmonyCoinType = 1023
+	// hardenedOffset is added to an index to derive a hardened child
+	hardenedOffset = 0x80000000
+)
+
+// GetMnemonic gets the mnemonic from env
+func GetMnemonic() string {
+	return os.Getenv("MNEMONIC")
+}
+
+// DeriveAccounts derives n private keys from the mnemonic using the Harmony BIP-44 path m/44'/1023'/0'/0/i
+// The mnemonic is validated first, a typo would otherwise derive a different set of accounts
+func DeriveAccounts(mnemonic string, n int) ([]*ecdsa.PrivateKey, error) {
+	words := strings.Fields(mnemonic)
+	if len(words) == 0 {
+		return nil, errors.New("mnemonic is empty")
+	}
+	mnemonic = strings.Join(words, " ")
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+	seed := MnemonicToSeed(mnemonic, "")
+
+	key, chainCode := masterKey(seed)
+	// m/44'/1023'/0'/0
+	for _, index := range []uint32{44 + hardenedOffset, HarmonyCoinType + hardenedOffset, 0 + hardenedOffset, 0} {
+		var err error
+		key, chainCode, err = deriveChild(key, chainCode, index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	accounts := make([]*ecdsa.PrivateKey, 0, n)
+	for i := 0; i < n; i++ {
+		childKey, _, err := deriveChild(key, chainCode, uint32(i))
+		if err != nil {
+			return nil, fmt.Errorf("deriving account %d: %w", i, err)
+		}
+		privateKey, err := crypto.ToECDSA(childKey)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, privateKey)
+	}
+	return accounts, nil
+}
+
+// ValidateMnemonic verifies the word count, that every word is in the english BIP-39 wordlist and the checksum
+func ValidateMnemonic(mnemonic string) error {
+	if _, err := bip39.EntropyFromMnemonic(mnemonic); err != nil {
+		return fmt.Errorf("invalid mnemonic: %w", err)
+	}
+	return nil
+}
+
+// MnemonicToSeed creates the BIP-39 seed from a mnemonic and passphrase
+// The mnemonic is expected to be the english wordlist, which needs no unicode normalization
+func MnemonicToSeed(mnemonic string, passphrase string) []byte {
+	return pbkdf2.Key([]byte(mnemonic), []byte("mnemonic"+passphrase), 2048, 64, sha512.New)
+}
+
+// masterKey creates the BIP-32 master key and chain code from a seed
+func masterKey(seed []byte) ([]byte, []byte) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
+
+// deriveChild performs BIP-32 private child key derivation
+func deriveChild(key []byte, chainCode []byte, index uint32) ([]byte, []byte, error) {
+	curveOrder := crypto.S256().Params().N
+
+	var data []byte
+	if index >= hardenedOffset {
+		data = append([]byte{0x00}, key...)
+	} else {
+		privateKey, err := crypto.ToECDSA(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		data = crypto.CompressPubkey(&privateKey.PublicKey)
+	}
+	indexBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(indexBytes, index)
+	data = append(data, indexBytes...)
+
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	il := new(big.Int).SetBytes(sum[:32])
+	if il.Cmp(curveOrder) >= 0 {
+		return nil, nil, errors.New("derived key is invalid, use the next index")
+	}
+	child := il.Add(il, new(big.Int).SetBytes(key))
+	child.Mod(child, curveOrder)
+	if child.Sign() == 0 {
+		return nil, nil, errors.New("derived key is invalid, use the next index")
+	}
+
+	childKey := make([]byte, 32)
+	child.FillBytes(childKey)
+	return childKey, sum[32:], nil
+}
@@ -0,0 +1,140 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcutil/bech32"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const abandonMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestMnemonicToSeed(t *testing.T) {
+	type testcase struct {
+		name       string
+		passphrase string
+		expected   string
+	}
+
+	// Vectors from the BIP-39 specification and its reference implementation
+	testCases := []testcase{
+		{
+			name:     "no_passphrase",
+			expected: "5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4",
+		}, {
+			name:       "trezor_passphrase",
+			passphrase: "TREZOR",
+			expected:   "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if seed := hex.EncodeToString(MnemonicToSeed(abandonMnemonic, tc.passphrase)); seed != tc.expected {
+				t.Errorf("seed is %s, expected %s", seed, tc.expected)
+			}
+		})
+	}
+}
+
+// TestDeriveChild follows test vector 1 of the BIP-32 specification, alternating hardened and normal children
+func TestDeriveChild(t *testing.T) {
+	type testcase struct {
+		path     string
+		index    uint32
+		expected string
+	}
+
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, chainCode := masterKey(seed)
+	if got := hex.EncodeToString(key); got != "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35" {
+		t.Fatalf("master key is %s", got)
+	}
+	if got := hex.EncodeToString(chainCode); got != "873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d508" {
+		t.Fatalf("master chain code is %s", got)
+	}
+
+	testCases := []testcase{
+		{path: "m/0'", index: 0 + hardenedOffset, expected: "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{path: "m/0'/1", index: 1, expected: "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{path: "m/0'/1/2'", index: 2 + hardenedOffset, expected: "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+		{path: "m/0'/1/2'/2", index: 2, expected: "0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4"},
+		{path: "m/0'/1/2'/2/1000000000", index: 1000000000, expected: "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+	}
+
+	for _, tc := range testCases {
+		key, chainCode, err = deriveChild(key, chainCode, tc.index)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.path, err)
+		}
+		if got := hex.EncodeToString(key); got != tc.expected {
+			t.Fatalf("%s is %s, expected %s", tc.path, got, tc.expected)
+		}
+	}
+}
+
+func TestDeriveAccounts(t *testing.T) {
+	type testcase struct {
+		name     string
+		mnemonic string
+		expected []string
+		wantErr  bool
+	}
+
+	// The addresses were cross checked against the BIP-32 implementation of btcutil/hdkeychain on m/44'/1023'/0'/0/i
+	testCases := []testcase{
+		{
+			name:     "abandon",
+			mnemonic: abandonMnemonic,
+			expected: []string{
+				"one1q6gkzcap0uruuu8r6sldxuu47pd4ww9w9t7tg6",
+				"one13c4p4dzqjtcu6wvkvhqkpesagng5tfsl625gqm",
+				"one1lyt6xhyymyd22qwp3vrq0dq03wanktqsereqtt",
+			},
+		}, {
+			name:     "extra_whitespace",
+			mnemonic: "  abandon abandon abandon abandon abandon abandon\n abandon abandon abandon abandon abandon  about ",
+			expected: []string{"one1q6gkzcap0uruuu8r6sldxuu47pd4ww9w9t7tg6"},
+		}, {
+			name:     "urge",
+			mnemonic: "urge clog right example dish drill card maximum mix bachelor section select",
+			expected: []string{"one166axnkjmghkf3df7xfvd0hn4dft8kemrza4cd2", "one1da7aytz6fws4s973nnzyyxllwyf89n92tvumuz"},
+		},
+		{name: "empty", mnemonic: "", wantErr: true},
+		{name: "bad_checksum", mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", wantErr: true},
+		{name: "word_not_in_wordlist", mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abuot", wantErr: true},
+		{name: "wrong_word_count", mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			keys, err := DeriveAccounts(tc.mnemonic, len(tc.expected))
+			if tc.wantErr {
+				if err == nil {
+					t.Error("expected the mnemonic to be rejected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, key := range keys {
+				converted, err := bech32.ConvertBits(crypto.PubkeyToAddress(key.PublicKey).Bytes(), 8, 5, true)
+				if err != nil {
+					t.Fatal(err)
+				}
+				address, err := bech32.Encode("one", converted)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if address != tc.expected[i] {
+					t.Errorf("account %d is %s, expected %s", i, address, tc.expected[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package crypto
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/term"
+)
+
+// Signer signs hashes on behalf of an account
+// Implementations does not have to expose the private key, which allows signing to happen outside of the tool
+type Signer interface {
+	// Address is the account that signatures are made for
+	Address() common.Address
+	// SignHash signs the 32 byte hash and returns the 65 byte [R || S || V] signature where V is 0 or 1
+	SignHash(hash []byte) ([]byte, error)
+}
+
+var (
+	signerOnce    sync.Once
+	defaultSigner Signer
+)
+
+// GetSigner returns the Signer configured in the .env file, it is created on the first call
+// SIGNER_COMMAND and ADDRESS selects an ExternalSigner
+// KEYSTORE_FILE selects an encrypted keystore, the passphrase is read from KEYSTORE_PASSPHRASE_FILE or prompted for
+// Otherwise the raw PRIVATE_KEY is used
+func GetSigner() Signer {
+	signerOnce.Do(func() {
+		signer, err := newSignerFromEnv()
+		if err != nil {
+			log.Fatal(err)
+		}
+		defaultSigner = signer
+	})
+	return defaultSigner
+}
+
+func newSignerFromEnv() (Signer, error) {
+	if command := os.Getenv("SIGNER_COMMAND"); command != "" {
+		address := os.Getenv("ADDRESS")
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("SIGNER_COMMAND needs ADDRESS to be a hex address, got %q", address)
+		}
+		return NewExternalSigner(common.HexToAddress(address), command), nil
+	}
+	if path := os.Getenv("KEYSTORE_FILE"); path != "" {
+		passphrase, err := GetPassphrase()
+		if err != nil {
+			return nil, err
+		}
+		return LoadKeystore(path, passphrase)
+	}
+	privateKey, err := crypto.HexToECDSA(os.Getenv("PRIVATE_KEY"))
+	if err != nil {
+		return nil, err
+	}
+	return NewKeySigner(privateKey), nil
+}
+
+// NewTransactor creates the TransactOpts used by contract bindings, signing is done by signer
+func NewTransactor(signer Signer, chainID *big.Int) *bind.TransactOpts {
+	ethSigner := types.LatestSignerForChainID(chainID)
+	return &bind.TransactOpts{
+		From: signer.Address(),
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != signer.Address() {
+				return nil, bind.ErrNotAuthorized
+			}
+			sig, err := signer.SignHash(ethSigner.Hash(tx).Bytes())
+			if err != nil {
+				return nil, err
+			}
+			return tx.WithSignature(ethSigner, sig)
+		},
+		Context: context.Background(),
+	}
+}
+
+// KeySigner signs using a private key held in memory
+type KeySigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+// NewKeySigner creates a Signer from a private key
+func NewKeySigner(key *ecdsa.PrivateKey) *KeySigner {
+	return &KeySigner{
+		key:     key,
+		address: crypto.PubkeyToAddress(key.PublicKey),
+	}
+}
+
+// Address returns the address of the private key
+func (ks *KeySigner) Address() common.Address {
+	return ks.address
+}
+
+// SignHash signs the hash with the private key
+func (ks *KeySigner) SignHash(hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, ks.key)
+}
+
+// LoadKeystore decrypts an Ethereum/Harmony encrypted keystore JSON file
+func LoadKeystore(path string, passphrase string) (*KeySigner, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keystore.DecryptKey(data, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting keystore %s: %w", path, err)
+	}
+	return NewKeySigner(key.PrivateKey), nil
+}
+
+// GetPassphrase reads the keystore passphrase from KEYSTORE_PASSPHRASE_FILE
+// or prompts for it if no file is configured
+func GetPassphrase() (string, error) {
+	if path := os.Getenv("KEYSTORE_PASSPHRASE_FILE"); path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", errors.New("KEYSTORE_PASSPHRASE_FILE is not set and stdin is not a terminal to prompt on")
+	}
+	fmt.Fprint(os.Stderr, "Keystore passphrase: ")
+	passphrase, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	return string(passphrase), nil
+}
+
+// ExternalSigner asks another process to sign, the key never enters this process
+// The command is executed with the address and the 0x prefixed hash as arguments
+// and has to print the 0x prefixed 65 byte signature to stdout
+type ExternalSigner struct {
+	address common.Address
+	command string
+}
+
+// NewExternalSigner creates a Signer that runs command for every signature
+func NewExternalSigner(address common.Address, command string) *ExternalSigner {
+	return &ExternalSigner{
+		address: address,
+		command: command,
+	}
+}
+
+// Address returns the address the external signer signs for
+func (es *ExternalSigner) Address() common.Address {
+	return es.address
+}
+
+// SignHash runs the external command and verifies that the signature belongs to the address
+func (es *ExternalSigner) SignHash(hash []byte) ([]byte, error) {
+	fields := strings.Fields(es.command)
+	if len(fields) == 0 {
+		return nil, errors.New("external signer has no command")
+	}
+	args := append(fields[1:], es.address.Hex(), hexutil.Encode(hash))
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(fields[0], args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("external signer failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	sig, err := hexutil.Decode(strings.TrimSpace(stdout.String()))
+	if err != nil {
+		return nil, fmt.Errorf("external signer returned a bad signature: %w", err)
+	}
+	if len(sig) != crypto.SignatureLength {
+		return nil, fmt.Errorf("external signer returned a signature of %d bytes, expected %d", len(sig), crypto.SignatureLength)
+	}
+	// Some signers use 27/28 for V
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pub, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return nil, err
+	}
+	if signer := crypto.PubkeyToAddress(*pub); signer != es.address {
+		return nil, fmt.Errorf("external signer signed with %s, expected %s", signer.Hex(), es.address.Hex())
+	}
+	return sig, nil
+}
@@ -0,0 +1,88 @@
+package harmony
+
+import (
+	"math/big"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ShardAccount is the state of an address on a single shard
+type ShardAccount struct {
+	ShardID int64    `json:"shardID"`
+	URL     string   `json:"url"`
+	Balance *big.Int `json:"balance,omitempty"`
+	Nonce   uint64   `json:"nonce"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// DelegationSummary sums up the delegations of an address, staking only happens on the beacon shard
+type DelegationSummary struct {
+	Validators   int      `json:"validators"`
+	Amount       *big.Int `json:"amount"`
+	Reward       *big.Int `json:"reward"`
+	Undelegating *big.Int `json:"undelegating"`
+	Error        string   `json:"error,omitempty"`
+}
+
+// AccountSummary is the state of an address across all shards
+type AccountSummary struct {
+	Hex         string            `json:"hex"`
+	Bech32      string            `json:"bech32"`
+	Shards      []ShardAccount    `json:"shards"`
+	Delegations DelegationSummary `json:"delegations"`
+}
+
+// GetShards returns the shards of the configured network
+func GetShards() ([]Shard, error) {
+	var shards shardingStructure
+	if _, err := CallMethod(URL, methods.METHOD_protocol_getShardingStructure, nil, &shards); err != nil {
+		return nil, err
+	}
+	return shards, nil
+}
+
+// GetAccountSummary fetches the balance and nonce of addr on every shard and its delegations on the beacon shard
+// Failures on a single shard are reported in that shard instead of failing the summary
+func GetAccountSummary(addr common.Address) (*AccountSummary, error) {
+	shards, err := GetShards()
+	if err != nil {
+		return nil, err
+	}
+	summary := &AccountSummary{Hex: addr.Hex(), Bech32: ToBech32(addr)}
+	beaconURL := URL
+	for _, shard := range shards {
+		account := ShardAccount{ShardID: shard.ShardID, URL: shard.HTTP}
+		if shard.ShardID == 0 {
+			beaconURL = shard.HTTP
+		}
+		var balance big.Int
+		var nonce hexutil.Uint64
+		if _, err := CallMethod(shard.HTTP, methods.METHOD_V2_getBalance, []interface{}{addr.Hex()}, &balance); err != nil {
+			account.Error = err.Error()
+		} else if _, err := CallMethod(shard.HTTP, methods.METHOD_V1_getTransactionCount, []interface{}{addr.Hex(), "latest"}, &nonce); err != nil {
+			account.Error = err.Error()
+		} else {
+			account.Balance = &balance
+			account.Nonce = uint64(nonce)
+		}
+		summary.Shards = append(summary.Shards, account)
+	}
+
+	delegations := DelegationSummary{Amount: new(big.Int), Reward: new(big.Int), Undelegating: new(big.Int)}
+	var result []DelegationByValidator
+	if _, err := CallMethod(beaconURL, methods.METHOD_staking_getDelegationsByDelegator, []interface{}{addr.Hex()}, &result); err != nil {
+		delegations.Error = err.Error()
+	}
+	for _, delegation := range result {
+		delegations.Validators++
+		delegations.Amount.Add(delegations.Amount, &delegation.Amount)
+		delegations.Reward.Add(delegations.Reward, &delegation.Reward)
+		for _, undelegation := range delegation.Undelegations {
+			delegations.Undelegating.Add(delegations.Undelegating, &undelegation.Amount)
+		}
+	}
+	summary.Delegations = delegations
+	return summary, nil
+}
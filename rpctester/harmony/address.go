@@ -2,6 +2,8 @@
 package harmony
 
 import (
+	"strings"
+
 	"github.com/btcsuite/btcutil/bech32"
 	ethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
@@ -59,6 +61,7 @@ func Bech32ToAddress(b32 string) (addr T, err error) {
 
 // ParseAddr parses the given address, either as bech32 or as hex.
 // The result can be 0x00..00 if the passing param is not a correct address.
+// Use ParseStrict to get an error instead.
 func Parse(s string) T {
 	if addr, err := Bech32ToAddress(s); err == nil {
 		return addr
@@ -87,3 +90,28 @@ func ConvertAndEncode(hrp string, data []byte) (string, error) {
 	return bech32.Encode(hrp, converted)
 
 }
+
+// ParseStrict parses the given address, either as bech32 or as hex, and
+// returns an error if it is malformed. Mixed case hex addresses have to carry
+// a valid EIP-55 checksum.
+func ParseStrict(s string) (T, error) {
+	switch {
+	case strings.HasPrefix(strings.ToLower(s), Bech32AddressHRP+"1"):
+		return Bech32ToAddress(s)
+	case strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X"):
+		hex := s[2:]
+		if len(hex) != 2*AddressLength {
+			return T{}, errors.Errorf("hex address %#v must have %d hex characters, got %d", s, 2*AddressLength, len(hex))
+		}
+		if !ethCommon.IsHexAddress(s) {
+			return T{}, errors.Errorf("hex address %#v contains non hex characters", s)
+		}
+		addr := ethCommon.HexToAddress(s)
+		// Hex() has a lower case prefix, so a 0X prefix is compared as 0x
+		if hex != strings.ToLower(hex) && hex != strings.ToUpper(hex) && addr.Hex() != "0x"+hex {
+			return T{}, errors.Errorf("hex address %#v has an invalid EIP-55 checksum, expected %s", s, addr.Hex())
+		}
+		return addr, nil
+	}
+	return T{}, errors.Errorf("%#v is neither a %s1 bech32 address nor a 0x hex address", s, Bech32AddressHRP)
+}
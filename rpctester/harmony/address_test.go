@@ -0,0 +1,54 @@
+package harmony
+
+import (
+	"testing"
+)
+
+// TestParseStrict only parses addresses, but like every test in the package it needs the .env and a reachable NET_URL for the package init
+func TestParseStrict(t *testing.T) {
+	const (
+		checksummed = "0xA5241513DA9F4463F1d4874b548dFBAC29D91f34"
+		bech32      = "one155jp2y76nazx8uw5sa94fr0m4s5aj8e5xm6fu3"
+	)
+	type testcase struct {
+		name    string
+		address string
+		wantErr bool
+	}
+
+	testCases := []testcase{
+		{name: "bech32", address: bech32},
+		{name: "bech32_upper_case", address: "ONE155JP2Y76NAZX8UW5SA94FR0M4S5AJ8E5XM6FU3"},
+		{name: "bech32_bad_checksum", address: "one155jp2y76nazx8uw5sa94fr0m4s5aj8e5xm6fu4", wantErr: true},
+		{name: "bech32_wrong_hrp", address: "two155jp2y76nazx8uw5sa94fr0m4s5aj8e5xm6fu3", wantErr: true},
+		{name: "bech32_truncated", address: "one155jp2y76nazx8uw5sa94fr0m4s5aj8e5", wantErr: true},
+		{name: "hex_checksummed", address: checksummed},
+		{name: "hex_checksummed_upper_prefix", address: "0X" + checksummed[2:]},
+		{name: "hex_lower_case", address: "0xa5241513da9f4463f1d4874b548dfbac29d91f34"},
+		{name: "hex_upper_case", address: "0xA5241513DA9F4463F1D4874B548DFBAC29D91F34"},
+		{name: "hex_bad_checksum", address: "0xa5241513DA9F4463F1d4874b548dFBAC29D91f34", wantErr: true},
+		{name: "hex_short", address: "0xa5241513da9f4463f1d4874b548dfbac29d91f", wantErr: true},
+		{name: "hex_long", address: checksummed + "00", wantErr: true},
+		{name: "hex_non_hex_characters", address: "0xg5241513da9f4463f1d4874b548dfbac29d91f34", wantErr: true},
+		{name: "hex_without_prefix", address: checksummed[2:], wantErr: true},
+		{name: "empty", address: "", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			addr, err := ParseStrict(tc.address)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("parsing %q returned %s, expected an error", tc.address, addr.Hex())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if addr.Hex() != checksummed {
+				t.Errorf("parsing %q returned %s, expected %s", tc.address, addr.Hex(), checksummed)
+			}
+		})
+	}
+}
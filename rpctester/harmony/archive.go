@@ -0,0 +1,191 @@
+package harmony
+
+import (
+	"fmt"
+	"math/big"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+const METRIC_archiveState = "archive_state"
+
+// Storage slots of the Devtoken state variables, Ownable and Stakeable come first in the layout
+const (
+	DevtokenTotalSupplySlot = 4
+	DevtokenBalancesSlot    = 8
+)
+
+// archiveDepth is how far behind the head a block has to be for its state to be pruned on a
+// non archival node, which only keeps the state of the last 128 blocks
+const archiveDepth = 256
+
+// StateQuery reads one piece of account or contract state at a block
+// Values are returned as strings so that every query compares the same way
+type StateQuery struct {
+	Name   string
+	Method string
+	Get    func(block int64) (string, error)
+}
+
+// DevtokenBalanceSlot returns the storage slot of _balances[account] in the Devtoken
+func DevtokenBalanceSlot(account common.Address) common.Hash {
+	key := common.LeftPadBytes(account.Bytes(), 32)
+	slot := common.LeftPadBytes(big.NewInt(DevtokenBalancesSlot).Bytes(), 32)
+	return ethcrypto.Keccak256Hash(key, slot)
+}
+
+// StateQueries returns the balance, nonce, Devtoken storage and hmy_call queries for account
+func StateQueries(account common.Address) []StateQuery {
+	balanceOf := func(block int64) (string, error) {
+		parsed, err := DevtokenABI()
+		if err != nil {
+			return "", err
+		}
+		data, err := parsed.Pack("balanceOf", account)
+		if err != nil {
+			return "", err
+		}
+		var result hexutil.Bytes
+		_, err = CallMethod(URL, methods.METHOD_contract_call, []interface{}{RpcCallArgs{
+			From: account.Hex(),
+			To:   SmartContractAddress.Hex(),
+			Data: hexutil.Encode(data),
+		}, EthBlockNumber(block)}, &result)
+		return result.String(), err
+	}
+	storageAt := func(slot common.Hash) func(block int64) (string, error) {
+		return func(block int64) (string, error) {
+			var result string
+			_, err := CallMethod(URL, methods.METHOD_contract_getStorageAt, []interface{}{SmartContractAddress.Hex(), slot.Hex(), EthBlockNumber(block)}, &result)
+			return common.HexToHash(result).Hex(), err
+		}
+	}
+
+	return []StateQuery{
+		{
+			Name:   "balance_V1",
+			Method: methods.METHOD_V1_getBalanceByBlockNumber,
+			Get: func(block int64) (string, error) {
+				var result hexutil.Big
+				_, err := CallMethod(URL, methods.METHOD_V1_getBalanceByBlockNumber, []interface{}{account.Hex(), EthBlockNumber(block)}, &result)
+				return result.ToInt().String(), err
+			},
+		},
+		{
+			Name:   "balance_V2",
+			Method: methods.METHOD_V2_getBalanceByBlockNumber,
+			Get: func(block int64) (string, error) {
+				var result big.Int
+				_, err := CallMethod(URL, methods.METHOD_V2_getBalanceByBlockNumber, []interface{}{account.Hex(), block}, &result)
+				return result.String(), err
+			},
+		},
+		{
+			Name:   "nonce_V1",
+			Method: methods.METHOD_V1_getTransactionCount,
+			Get: func(block int64) (string, error) {
+				var result hexutil.Uint64
+				_, err := CallMethod(URL, methods.METHOD_V1_getTransactionCount, []interface{}{account.Hex(), EthBlockNumber(block)}, &result)
+				return fmt.Sprint(uint64(result)), err
+			},
+		},
+		{
+			Name:   "nonce_V2",
+			Method: methods.METHOD_V2_getTransactionCount,
+			Get: func(block int64) (string, error) {
+				var result big.Int
+				_, err := CallMethod(URL, methods.METHOD_V2_getTransactionCount, []interface{}{account.Hex(), block}, &result)
+				return result.String(), err
+			},
+		},
+		{
+			Name:   "token_total_supply_slot",
+			Method: methods.METHOD_contract_getStorageAt,
+			Get:    storageAt(common.BigToHash(big.NewInt(DevtokenTotalSupplySlot))),
+		},
+		{
+			Name:   "token_balance_slot",
+			Method: methods.METHOD_contract_getStorageAt,
+			Get:    storageAt(DevtokenBalanceSlot(account)),
+		},
+		{
+			Name:   "token_balance_call",
+			Method: methods.METHOD_contract_call,
+			Get:    balanceOf,
+		},
+	}
+}
+
+// StateSnapshot holds the value of every state query at a block
+type StateSnapshot struct {
+	Block  int64
+	Values map[string]string
+}
+
+// TakeStateSnapshot runs every query at block, the block should be recent so that any node still has its state
+func TakeStateSnapshot(queries []StateQuery, block int64) (*StateSnapshot, error) {
+	snapshot := &StateSnapshot{Block: block, Values: make(map[string]string, len(queries))}
+	for _, query := range queries {
+		value, err := query.Get(block)
+		if err != nil {
+			return nil, fmt.Errorf("%s at block %d: %w", query.Name, block, err)
+		}
+		snapshot.Values[query.Name] = value
+	}
+	return snapshot, nil
+}
+
+// CompareHistoricalState queries the state at a recorded block again and compares it to the recorded value
+// A value that differs from the recording but matches latest means the node serves latest state for old blocks
+func CompareHistoricalState(query StateQuery, recorded *StateSnapshot, latest *StateSnapshot) error {
+	value, err := query.Get(recorded.Block)
+	if err != nil {
+		return fmt.Errorf("%s at block %d: state is missing: %w", query.Name, recorded.Block, err)
+	}
+	want := recorded.Values[query.Name]
+	if value == want {
+		return nil
+	}
+	if latest != nil && value == latest.Values[query.Name] {
+		return fmt.Errorf("%s at block %d returned the latest state %s, recorded %s", query.Name, recorded.Block, value, want)
+	}
+	return fmt.Errorf("%s at block %d returned %s, recorded %s", query.Name, recorded.Block, value, want)
+}
+
+// ArchiveReport tells if the node serves the state of blocks older than archiveDepth
+type ArchiveReport struct {
+	Archival bool
+	// Known is false if the chain is too short to tell
+	Known  bool
+	Block  int64
+	Reason string
+}
+
+// CheckArchival runs every query at a block archiveDepth behind latest, a pruning node has no state for it
+func CheckArchival(queries []StateQuery, latest int64) ArchiveReport {
+	block := latest - archiveDepth
+	if block < 1 {
+		return ArchiveReport{Block: block, Reason: fmt.Sprintf("the chain is only %d blocks long, state is pruned after %d blocks", latest, archiveDepth)}
+	}
+	for _, query := range queries {
+		if _, err := query.Get(block); err != nil {
+			return ArchiveReport{Known: true, Block: block, Reason: fmt.Sprintf("%s at block %d: %v", query.Name, block, err)}
+		}
+	}
+	return ArchiveReport{Archival: true, Known: true, Block: block, Reason: fmt.Sprintf("state at block %d, %d blocks behind the head, is served", block, archiveDepth)}
+}
+
+// String describes the report in a single line
+func (r ArchiveReport) String() string {
+	switch {
+	case !r.Known:
+		return "unknown if the node is archival: " + r.Reason
+	case r.Archival:
+		return "node is archival: " + r.Reason
+	default:
+		return "node is not archival: " + r.Reason
+	}
+}
@@ -0,0 +1,100 @@
+package harmony
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// archiveSteps is the amount of mints the state is recorded around
+const archiveSteps = 3
+
+// test_archiveState records the balance, nonce and Devtoken state of the test account at several blocks while minting,
+// then queries every block again and compares. Every mint changes all recorded values, so a node returning the latest
+// state for an old block is caught. Whether the node keeps state older than archiveDepth blocks is reported as well
+func (ts *testSuite) test_archiveState(t *testing.T) {
+	account := common.HexToAddress(TestAddress)
+	queries := StateQueries(account)
+
+	head, err := BlockNumber()
+	if err != nil {
+		failMetric(t, METRIC_archiveState, err)
+		return
+	}
+	// Record the state right away, recent blocks are served by every node
+	snapshot, err := TakeStateSnapshot(queries, head)
+	if err != nil {
+		failMetric(t, METRIC_archiveState, err)
+		return
+	}
+	snapshots := []*StateSnapshot{snapshot}
+	for i := 0; i < archiveSteps; i++ {
+		snapshot, err := mintAndSnapshot(queries, account)
+		if err != nil {
+			failMetric(t, METRIC_archiveState, err)
+			return
+		}
+		previous := snapshots[len(snapshots)-1]
+		for _, query := range queries {
+			if snapshot.Values[query.Name] == previous.Values[query.Name] {
+				failMetric(t, query.Method, fmt.Errorf("%s did not change from block %d to %d after minting", query.Name, previous.Block, snapshot.Block))
+				return
+			}
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	// The last snapshot is the latest state that old blocks are compared to
+	latest := snapshots[len(snapshots)-1]
+
+	for _, recorded := range snapshots[:len(snapshots)-1] {
+		for _, query := range queries {
+			t.Run(fmt.Sprintf("%s_block_%d", query.Name, recorded.Block), func(t *testing.T) {
+				start := time.Now()
+				if err := CompareHistoricalState(query, recorded, latest); err != nil {
+					failMetric(t, query.Method, err)
+					return
+				}
+				passMetric(t, query.Method, start)
+			})
+		}
+	}
+
+	t.Run("archival", func(t *testing.T) {
+		start := time.Now()
+		report := CheckArchival(queries, latest.Block)
+		// A pruning node is not broken, so it is reported but does not fail the test
+		t.Log(report)
+		metric := TestMetric{
+			Method:   METRIC_archiveState,
+			Test:     t.Name(),
+			Pass:     report.Archival,
+			Duration: time.Since(start).String(),
+		}
+		if !report.Archival {
+			metric.Error = report.String()
+		}
+		testMetrics = append(testMetrics, metric)
+	})
+}
+
+// mintAndSnapshot mints Devtokens to account and records the state at the block the mint was included in
+func mintAndSnapshot(queries []StateQuery, account common.Address) (*StateSnapshot, error) {
+	hash, err := SendDevtokenTransaction("mint", account, big.NewInt(1000))
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+	receipt, err := WaitForReceipt(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != 1 {
+		return nil, fmt.Errorf("mint %s failed with status %d", hash, receipt.Status)
+	}
+	return TakeStateSnapshot(queries, receipt.BlockNumber)
+}
@@ -0,0 +1,72 @@
+package harmony
+
+import (
+	"fmt"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+)
+
+// GetBlockByNumber fetches block n using hmyv2_getBlockByNumber
+func GetBlockByNumber(n int64, args BlockArgs) (*BlockV2, error) {
+	var block *BlockV2
+	if _, err := CallMethod(URL, methods.METHOD_transaction_V2_getBlockByNumber, []interface{}{n, args}, &block); err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %d not found", n)
+	}
+	return block, nil
+}
+
+// GetBlocks fetches the blocks from and including from to and including to using hmyv2_getBlocks
+func GetBlocks(from, to int64, args BlockArgs) ([]BlockV2, error) {
+	var blocks []BlockV2
+	if _, err := CallMethod(URL, methods.METHOD_transaction_V2_getBlocks, []interface{}{from, to, args}, &blocks); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// GetBlockTransactionCountByNumber returns the amount of transactions in block n
+func GetBlockTransactionCountByNumber(n int64) (int64, error) {
+	var count int64
+	if _, err := CallMethod(URL, methods.METHOD_transaction_V2_getBlockTransactionCountByNumber, []interface{}{n}, &count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetTransactionByHash fetches a transaction using hmyv2_getTransactionByHash
+func GetTransactionByHash(hash string) (*TransactionByHashV2, error) {
+	var tx *TransactionByHashV2
+	if _, err := CallMethod(URL, methods.METHOD_transaction_V2_getTransactionByHash, []interface{}{hash}, &tx); err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("transaction %s not found", hash)
+	}
+	return tx, nil
+}
+
+// GetTransactionByBlockNumberAndIndex fetches the transaction at index in block n
+func GetTransactionByBlockNumberAndIndex(n int64, index int) (*TransactionByHashV2, error) {
+	var tx *TransactionByHashV2
+	if _, err := CallMethod(URL, methods.METHOD_transaction_V2_getTransactionByBlockNumberAndIndex, []interface{}{n, index}, &tx); err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("transaction %d in block %d not found", index, n)
+	}
+	return tx, nil
+}
+
+// GetTransactionReceipt fetches the receipt of a transaction without waiting for it
+func GetTransactionReceipt(hash string) (*TransactionReceipt_V2, error) {
+	var receipt *TransactionReceipt_V2
+	if _, err := CallMethod(URL, methods.METHOD_transaction_V2_getTransactionReceipt, []interface{}{hash}, &receipt); err != nil {
+		return nil, err
+	}
+	if receipt == nil {
+		return nil, fmt.Errorf("receipt of %s not found", hash)
+	}
+	return receipt, nil
+}
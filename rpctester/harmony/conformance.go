@@ -0,0 +1,361 @@
+package harmony
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"strings"
+	"time"
+)
+
+const METRIC_conformance = "jsonrpc_conformance"
+
+var (
+	// ErrInvalidVersion is returned when a response is not tagged with jsonrpc 2.0
+	ErrInvalidVersion = errors.New("response jsonrpc version is not 2.0")
+	// ErrIDMismatch is returned when the response id is not the id of the request
+	ErrIDMismatch = errors.New("response id does not match the request id")
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	CodeParseError     int64 = -32700
+	CodeInvalidRequest int64 = -32600
+	CodeMethodNotFound int64 = -32601
+	CodeInvalidParams  int64 = -32602
+	CodeInternalError  int64 = -32603
+)
+
+// ConformanceCheck is the outcome of a single JSON-RPC 2.0 conformance check
+type ConformanceCheck struct {
+	Name     string `json:"name"`
+	Request  string `json:"request"`
+	Pass     bool   `json:"pass"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+// ConformanceReport scores an endpoint against the JSON-RPC 2.0 specification
+type ConformanceReport struct {
+	URL    string             `json:"url"`
+	Passed int                `json:"passed"`
+	Total  int                `json:"total"`
+	Score  float64            `json:"score"`
+	Checks []ConformanceCheck `json:"checks"`
+}
+
+// rawResponse is the reply of a raw HTTP exchange with the endpoint
+type rawResponse struct {
+	Status int
+	Body   []byte
+}
+
+// conformanceCase sends a raw request and verifies the reply
+type conformanceCase struct {
+	name        string
+	httpMethod  string
+	contentType string
+	body        string
+	verify      func(resp rawResponse) error
+}
+
+// conformanceCases are the checks every endpoint is scored on
+func conformanceCases() []conformanceCase {
+	valid := methods.METHOD_protocol_V1_blockNumber
+	request := func(id string, method string, params string) string {
+		if id == "" {
+			return fmt.Sprintf(`{"jsonrpc":"2.0","method":%q,"params":%s}`, method, params)
+		}
+		return fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"method":%q,"params":%s}`, id, method, params)
+	}
+	return []conformanceCase{
+		{
+			name: "id_string",
+			body: request(`"conformance"`, valid, "[]"),
+			verify: func(resp rawResponse) error {
+				return expectSingle(resp, `"conformance"`, 0)
+			},
+		}, {
+			name: "id_number",
+			body: request("7", valid, "[]"),
+			verify: func(resp rawResponse) error {
+				return expectSingle(resp, "7", 0)
+			},
+		}, {
+			name: "id_null",
+			body: request("null", valid, "[]"),
+			verify: func(resp rawResponse) error {
+				return expectSingle(resp, "null", 0)
+			},
+		}, {
+			name: "notification",
+			body: request("", valid, "[]"),
+			verify: func(resp rawResponse) error {
+				if err := expectStatus(resp, http.StatusOK, http.StatusNoContent); err != nil {
+					return err
+				}
+				if len(bytes.TrimSpace(resp.Body)) != 0 {
+					return fmt.Errorf("notification was answered with %s", resp.Body)
+				}
+				return nil
+			},
+		}, {
+			name: "parse_error",
+			body: `{"jsonrpc":"2.0","id":1,"method":`,
+			verify: func(resp rawResponse) error {
+				return expectSingle(resp, "null", CodeParseError)
+			},
+		}, {
+			name: "invalid_request_method_type",
+			body: `{"jsonrpc":"2.0","id":1,"method":1,"params":[]}`,
+			verify: func(resp rawResponse) error {
+				return expectSingle(resp, "", CodeInvalidRequest)
+			},
+		}, {
+			name: "invalid_request_not_object",
+			body: `"request"`,
+			verify: func(resp rawResponse) error {
+				return expectSingle(resp, "null", CodeInvalidRequest)
+			},
+		}, {
+			name: "invalid_request_version",
+			body: fmt.Sprintf(`{"jsonrpc":"1.0","id":1,"method":%q,"params":[]}`, valid),
+			verify: func(resp rawResponse) error {
+				return expectSingle(resp, "", CodeInvalidRequest)
+			},
+		}, {
+			name: "method_not_found",
+			body: request("3", "hmy_conformanceDoesNotExist", "[]"),
+			verify: func(resp rawResponse) error {
+				return expectSingle(resp, "3", CodeMethodNotFound)
+			},
+		}, {
+			name: "invalid_params",
+			body: request("4", valid, `[1,2,3]`),
+			verify: func(resp rawResponse) error {
+				return expectSingle(resp, "4", CodeInvalidParams)
+			},
+		}, {
+			name: "batch_empty",
+			body: `[]`,
+			verify: func(resp rawResponse) error {
+				return expectSingle(resp, "null", CodeInvalidRequest)
+			},
+		}, {
+			name: "batch_invalid",
+			body: `[1,2]`,
+			verify: func(resp rawResponse) error {
+				return expectBatch(resp, []batchExpectation{{"null", CodeInvalidRequest}, {"null", CodeInvalidRequest}})
+			},
+		}, {
+			name: "batch_mixed",
+			body: "[" + strings.Join([]string{
+				request("1", valid, "[]"),
+				`1`,
+				request("", valid, "[]"),
+				request("3", "hmy_conformanceDoesNotExist", "[]"),
+			}, ",") + "]",
+			verify: func(resp rawResponse) error {
+				return expectBatch(resp, []batchExpectation{{"1", 0}, {"null", CodeInvalidRequest}, {"3", CodeMethodNotFound}})
+			},
+		}, {
+			name:        "content_type_charset",
+			contentType: "application/json; charset=utf-8",
+			body:        request("5", valid, "[]"),
+			verify: func(resp rawResponse) error {
+				return expectSingle(resp, "5", 0)
+			},
+		}, {
+			name:        "content_type_unsupported",
+			contentType: "text/plain",
+			body:        request("6", valid, "[]"),
+			verify: func(resp rawResponse) error {
+				return expectStatus(resp, http.StatusUnsupportedMediaType)
+			},
+		}, {
+			name:       "get_request",
+			httpMethod: http.MethodGet,
+			body:       request("8", valid, "[]"),
+			verify: func(resp rawResponse) error {
+				// GET is either not supported at all or answered like a POST, silently ignoring it is not allowed
+				if resp.Status == http.StatusMethodNotAllowed {
+					return nil
+				}
+				if expectSingle(resp, "8", 0) == nil {
+					return nil
+				}
+				return fmt.Errorf("GET must be rejected with %d or answered with a response, got %d %q", http.StatusMethodNotAllowed, resp.Status, resp.Body)
+			},
+		}, {
+			name:       "put_request",
+			httpMethod: http.MethodPut,
+			body:       request("9", valid, "[]"),
+			verify: func(resp rawResponse) error {
+				return expectStatus(resp, http.StatusMethodNotAllowed)
+			},
+		},
+	}
+}
+
+// CheckConformance runs every conformance check against the endpoint and scores it
+func CheckConformance(endpoint string) ConformanceReport {
+	report := ConformanceReport{URL: endpoint}
+	for _, c := range conformanceCases() {
+		check := ConformanceCheck{Name: c.name, Request: c.body}
+		start := time.Now()
+		resp, err := sendRaw(endpoint, c.httpMethod, c.contentType, c.body)
+		check.Duration = time.Since(start).String()
+		if err == nil {
+			err = c.verify(resp)
+		}
+		if err != nil {
+			check.Error = err.Error()
+		} else {
+			check.Pass = true
+			report.Passed++
+		}
+		report.Checks = append(report.Checks, check)
+	}
+	report.Total = len(report.Checks)
+	if report.Total > 0 {
+		report.Score = float64(report.Passed) / float64(report.Total)
+	}
+	return report
+}
+
+// sendRaw sends body to the endpoint as is, GET requests carry the body in the query string
+func sendRaw(endpoint string, httpMethod string, contentType string, body string) (rawResponse, error) {
+	if httpMethod == "" {
+		httpMethod = http.MethodPost
+	}
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	var (
+		req *http.Request
+		err error
+	)
+	if httpMethod == http.MethodGet {
+		req, err = http.NewRequest(httpMethod, endpoint+"?"+url.Values{"request": {body}}.Encode(), nil)
+	} else {
+		req, err = http.NewRequest(httpMethod, endpoint, strings.NewReader(body))
+	}
+	if err != nil {
+		return rawResponse{}, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return rawResponse{}, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return rawResponse{}, err
+	}
+	return rawResponse{Status: resp.StatusCode, Body: data}, nil
+}
+
+func expectStatus(resp rawResponse, statuses ...int) error {
+	for _, status := range statuses {
+		if resp.Status == status {
+			return nil
+		}
+	}
+	return fmt.Errorf("expected HTTP status %v, got %d", statuses, resp.Status)
+}
+
+// expectSingle verifies a single response object, an empty id skips the id check and a zero code expects a result
+func expectSingle(resp rawResponse, id string, code int64) error {
+	if err := expectStatus(resp, http.StatusOK); err != nil {
+		return err
+	}
+	return verifyResponseObject(bytes.TrimSpace(resp.Body), id, code)
+}
+
+type batchExpectation struct {
+	id   string
+	code int64
+}
+
+// expectBatch verifies that a batch holds exactly one response per expectation, matched by id in any order
+func expectBatch(resp rawResponse, expected []batchExpectation) error {
+	if err := expectStatus(resp, http.StatusOK); err != nil {
+		return err
+	}
+	var batch []json.RawMessage
+	if err := json.Unmarshal(resp.Body, &batch); err != nil {
+		return fmt.Errorf("batch response is not an array: %s", resp.Body)
+	}
+	if len(batch) != len(expected) {
+		return fmt.Errorf("expected %d responses in batch, got %d", len(expected), len(batch))
+	}
+	used := make([]bool, len(batch))
+	for _, exp := range expected {
+		found := false
+		for i, item := range batch {
+			if used[i] {
+				continue
+			}
+			if verifyResponseObject(item, exp.id, exp.code) == nil {
+				used[i], found = true, true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("no response with id %s and code %d in batch %s", exp.id, exp.code, resp.Body)
+		}
+	}
+	return nil
+}
+
+// verifyResponseObject checks the version, id and that exactly one of result and error is present
+func verifyResponseObject(data []byte, id string, code int64) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("response is not an object: %s", data)
+	}
+	if string(fields["jsonrpc"]) != `"2.0"` {
+		return fmt.Errorf("%w: %s", ErrInvalidVersion, fields["jsonrpc"])
+	}
+	respID, ok := fields["id"]
+	if !ok {
+		return errors.New("response has no id")
+	}
+	if id != "" && string(respID) != id {
+		return fmt.Errorf("%w: expected %s, got %s", ErrIDMismatch, id, respID)
+	}
+	result, hasResult := fields["result"]
+	rawError, hasError := fields["error"]
+	if hasResult == hasError {
+		return fmt.Errorf("response must have either result or error: %s", data)
+	}
+	if code == 0 {
+		if hasError {
+			return fmt.Errorf("expected a result, got error %s", rawError)
+		}
+		if len(result) == 0 {
+			return errors.New("empty result")
+		}
+		return nil
+	}
+	if hasResult {
+		return fmt.Errorf("expected error %d, got result %s", code, result)
+	}
+	var rpcErr struct {
+		Code    *int64  `json:"code"`
+		Message *string `json:"message"`
+	}
+	if err := json.Unmarshal(rawError, &rpcErr); err != nil || rpcErr.Code == nil || rpcErr.Message == nil {
+		return fmt.Errorf("error object needs an integer code and a string message: %s", rawError)
+	}
+	if *rpcErr.Code != code {
+		return fmt.Errorf("expected error code %d, got %d: %s", code, *rpcErr.Code, *rpcErr.Message)
+	}
+	return nil
+}
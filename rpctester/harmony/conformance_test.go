@@ -0,0 +1,27 @@
+package harmony
+
+import (
+	"testing"
+)
+
+// test_Conformance scores the endpoint against the JSON-RPC 2.0 specification, every check is its own metric
+func test_Conformance(t *testing.T) {
+	report := CheckConformance(URL)
+	for _, check := range report.Checks {
+		check := check
+		t.Run(check.Name, func(t *testing.T) {
+			testMetrics = append(testMetrics, TestMetric{
+				Method:   METRIC_conformance,
+				Test:     check.Name,
+				Pass:     check.Pass,
+				Duration: check.Duration,
+				Error:    check.Error,
+				Params:   []interface{}{check.Request},
+			})
+			if !check.Pass {
+				t.Error(check.Error)
+			}
+		})
+	}
+	t.Logf("%s scored %d/%d", report.URL, report.Passed, report.Total)
+}
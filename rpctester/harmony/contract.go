@@ -0,0 +1,53 @@
+package harmony
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"percybolmer/rpc-shard-testing/rpctester/contracts/devtoken"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// GetCode fetches the deployed bytecode at address using hmy_getCode
+func GetCode(address common.Address) ([]byte, error) {
+	var code hexutil.Bytes
+	if _, err := CallMethod(URL, methods.METHOD_contract_getCode, []interface{}{address.Hex(), "latest"}, &code); err != nil {
+		return nil, err
+	}
+	return code, nil
+}
+
+// DevtokenRuntimeCode returns the code the Devtoken creation code leaves on chain
+// solc ends the creation code with RETURN INVALID (0xf3fe), followed by the runtime code
+func DevtokenRuntimeCode() ([]byte, error) {
+	bin := common.FromHex(devtoken.DevtokenBin)
+	index := bytes.Index(bin, []byte{0xf3, 0xfe, 0x60, 0x80, 0x60, 0x40, 0x52})
+	if index < 0 {
+		return nil, errors.New("could not find the runtime code in the devtoken bytecode")
+	}
+	return bin[index+2:], nil
+}
+
+// VerifyDevtokenCode checks that the code deployed at address is the Devtoken and returns its code hash
+func VerifyDevtokenCode(address common.Address) (common.Hash, error) {
+	expected, err := DevtokenRuntimeCode()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	code, err := GetCode(address)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if len(code) == 0 {
+		return common.Hash{}, fmt.Errorf("no code deployed at %s", address.Hex())
+	}
+	codeHash := ethcrypto.Keccak256Hash(code)
+	if expectedHash := ethcrypto.Keccak256Hash(expected); codeHash != expectedHash {
+		return codeHash, fmt.Errorf("code hash at %s is %s, expected %s", address.Hex(), codeHash.Hex(), expectedHash.Hex())
+	}
+	return codeHash, nil
+}
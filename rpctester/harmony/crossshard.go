@@ -0,0 +1,152 @@
+package harmony
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"percybolmer/rpc-shard-testing/rpctester/crypto"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// METRIC_crossShardSettlement is the name used when reporting the end to end latency of a cross shard transaction
+	METRIC_crossShardSettlement = "cross_shard_settlement"
+	// crossShardPollInterval is how often the shards are asked about the cross shard transaction
+	crossShardPollInterval = 1 * time.Second
+)
+
+// CrossShardResult contains the information gathered while following a cross shard transaction
+type CrossShardResult struct {
+	TransactionHash string
+	FromShardID     uint32
+	ToShardID       uint32
+	// SourceReceipt is the receipt of the transaction on the sending shard
+	SourceReceipt TransactionReceipt_V2
+	// CXReceipt is the cross shard receipt fetched using getCXReceiptByHash
+	CXReceipt Reciept
+	// SeenPending is true if the receipt was found in getPendingCXReceipts on the destination shard
+	SeenPending bool
+	// SourceLatency is the time from submitting the transaction until it was included on the sending shard
+	SourceLatency time.Duration
+	// SettlementLatency is the time from submitting the transaction until the destination shard was credited
+	SettlementLatency time.Duration
+}
+
+// GetShardURL uses the sharding structure of the configured network to find the RPC endpoint of a shard
+func GetShardURL(shardID uint32) (string, error) {
+	shards, err := GetShards()
+	if err != nil {
+		return "", err
+	}
+	for _, shard := range shards {
+		if shard.ShardID == int64(shardID) {
+			return shard.HTTP, nil
+		}
+	}
+	return "", fmt.Errorf("shard %d is not part of the sharding structure", shardID)
+}
+
+// CrossShardDestination returns the shard that cross shard transactions are sent to
+// Shard 0 sends to shard 1, all other shards send to shard 0
+func CrossShardDestination() (uint32, error) {
+	shardID, err := crypto.GetShardID()
+	if err != nil {
+		return 0, err
+	}
+	if shardID == 0 {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// SendCrossShardTransaction transfers amount from the TestAddress on the configured shard to the TestAddress on toShardID
+// It will follow the transaction on both shards until the destination shard has been credited, or ctx is cancelled
+func SendCrossShardTransaction(ctx context.Context, toShardID uint32, amount *big.Int) (*CrossShardResult, error) {
+	fromShardID, err := crypto.GetShardID()
+	if err != nil {
+		return nil, err
+	}
+	destinationURL, err := GetShardURL(toShardID)
+	if err != nil {
+		return nil, err
+	}
+
+	var balanceBefore big.Int
+	if _, err := CallMethod(destinationURL, methods.METHOD_V2_getBalance, []interface{}{TestAddress}, &balanceBefore); err != nil {
+		return nil, err
+	}
+
+	addr := common.HexToAddress(TestAddress)
+	rlp, err := CreateCrossShardRLPString(addr, addr, *amount, nil, toShardID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CrossShardResult{
+		FromShardID: uint32(fromShardID),
+		ToShardID:   toShardID,
+	}
+	start := time.Now()
+	result.TransactionHash, err = SendRawTransaction(rlp)
+	if err != nil {
+		GetNonceManager(addr).Resync()
+		return nil, err
+	}
+
+	// Wait for the transaction to be included on the sending shard
+	receipt, err := WaitForReceipt(ctx, result.TransactionHash)
+	if err != nil {
+		return result, fmt.Errorf("waiting for receipt on shard %d: %w", fromShardID, err)
+	}
+	result.SourceReceipt = *receipt
+	result.SourceLatency, _ = InclusionLatency(result.TransactionHash)
+
+	// The sending shard produces the CX receipt
+	err = pollWithBackoff(ctx, crossShardPollInterval, crossShardPollInterval, func() (bool, error) {
+		if _, err := CallMethod(URL, methods.METHOD_transaction_V2_getCXReceiptByHash, []interface{}{result.TransactionHash}, &result.CXReceipt); err != nil {
+			return false, err
+		}
+		return result.CXReceipt.Hash != "", nil
+	})
+	if err != nil {
+		return result, fmt.Errorf("waiting for cx receipt on shard %d: %w", fromShardID, err)
+	}
+
+	// Wait for the destination shard to credit the amount
+	expected := big.NewInt(0).Add(&balanceBefore, amount)
+	err = pollWithBackoff(ctx, crossShardPollInterval, crossShardPollInterval, func() (bool, error) {
+		if !result.SeenPending {
+			var pending []PendingCXReceipt
+			if _, err := CallMethod(destinationURL, methods.METHOD_transaction_V2_getPendingCXReceipts, nil, &pending); err == nil {
+				result.SeenPending = containsCXReceipt(pending, result.TransactionHash)
+			}
+		}
+		var balance big.Int
+		if _, err := CallMethod(destinationURL, methods.METHOD_V2_getBalance, []interface{}{TestAddress}, &balance); err != nil {
+			return false, err
+		}
+		return balance.Cmp(expected) >= 0, nil
+	})
+	if err != nil {
+		return result, fmt.Errorf("waiting for settlement on shard %d: %w", toShardID, err)
+	}
+	result.SettlementLatency = time.Since(start)
+
+	return result, nil
+}
+
+// containsCXReceipt checks if any of the pending proofs contains a receipt for the transaction
+func containsCXReceipt(pending []PendingCXReceipt, txHash string) bool {
+	for _, proof := range pending {
+		for _, receipt := range proof.Receipts {
+			if strings.EqualFold(receipt.TxHash, txHash) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package harmony
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func (ts *testSuite) test_crossShardTransaction(t *testing.T) {
+	toShardID, err := CrossShardDestination()
+	if err != nil {
+		t.Skip("cannot select destination shard: ", err)
+	}
+	if _, err := GetShardURL(toShardID); err != nil {
+		t.Skip("network has no destination shard: ", err)
+	}
+
+	type testcase struct {
+		name    string
+		amount  *big.Int
+		timeout time.Duration
+	}
+
+	testCases := []testcase{
+		{
+			name: fmt.Sprintf("%s_shard_to_shard_%d", t.Name(), toShardID),
+			// Transfer 0.001 ONE
+			amount:  big.NewInt(0).Div(ONE, big.NewInt(1000)),
+			timeout: 2 * time.Minute,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+
+			result, err := SendCrossShardTransaction(ctx, toShardID, tc.amount)
+			if err != nil {
+				testMetrics = append(testMetrics, TestMetric{
+					Method: METRIC_crossShardSettlement,
+					Test:   tc.name,
+					Pass:   false,
+					Error:  err.Error(),
+				})
+				t.Error(err)
+				return
+			}
+			ts.LastCrossShardTransactionHash = result.TransactionHash
+			ts.LastCrossShardToShardID = result.ToShardID
+
+			// Verify that the CX receipt describes the transaction sent
+			cx := result.CXReceipt
+			if cx.ShardID.Int64() != int64(result.FromShardID) || cx.ToShardID.Int64() != int64(result.ToShardID) || cx.Value.Cmp(tc.amount) != 0 {
+				err := fmt.Errorf("cx receipt mismatch: shard %s -> %s value %s", cx.ShardID.String(), cx.ToShardID.String(), cx.Value.String())
+				testMetrics = append(testMetrics, TestMetric{
+					Method:   METRIC_crossShardSettlement,
+					Test:     tc.name,
+					Pass:     false,
+					Duration: result.SettlementLatency.String(),
+					Error:    err.Error(),
+				})
+				t.Error(err)
+				return
+			}
+			if !result.SeenPending {
+				t.Log("cross shard receipt was never seen in pending CX receipts, it may have been processed between polls")
+			}
+
+			testMetrics = append(testMetrics, TestMetric{
+				Method:   METRIC_crossShardSettlement,
+				Test:     tc.name,
+				Pass:     true,
+				Duration: result.SettlementLatency.String(),
+			})
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package harmony
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"percybolmer/rpc-shard-testing/rpctester/contracts/devtoken"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// METRIC_devtokenWorkflow is the name used when reporting the steps of the Devtoken workflow
+const METRIC_devtokenWorkflow = "devtoken_workflow"
+
+var (
+	devtokenABIOnce sync.Once
+	devtokenABI     abi.ABI
+	devtokenABIErr  error
+)
+
+// DevtokenABI returns the parsed ABI of the Devtoken contract
+func DevtokenABI() (abi.ABI, error) {
+	devtokenABIOnce.Do(func() {
+		devtokenABI, devtokenABIErr = abi.JSON(strings.NewReader(devtoken.DevtokenABI))
+	})
+	return devtokenABI, devtokenABIErr
+}
+
+// CallDevtoken performs a read only call to the deployed Devtoken using hmy_call and unpacks the outputs
+func CallDevtoken(method string, args ...interface{}) ([]interface{}, error) {
+	parsed, err := DevtokenABI()
+	if err != nil {
+		return nil, err
+	}
+	data, err := parsed.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	var result hexutil.Bytes
+	if _, err := CallMethod(URL, methods.METHOD_contract_call, []interface{}{RpcCallArgs{
+		From: common.HexToAddress(TestAddress).Hex(),
+		To:   SmartContractAddress.Hex(),
+		Data: hexutil.Encode(data),
+	}, "latest"}, &result); err != nil {
+		return nil, err
+	}
+	return parsed.Unpack(method, result)
+}
+
+// TokenBalanceOf returns the Devtoken balance of account using hmy_call
+func TokenBalanceOf(account common.Address) (*big.Int, error) {
+	out, err := CallDevtoken("balanceOf", account)
+	if err != nil {
+		return nil, err
+	}
+	balance, ok := out[0].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("balanceOf returned %T", out[0])
+	}
+	return balance, nil
+}
+
+// SendDevtokenTransaction sends a state changing call to the deployed Devtoken from the configured account
+func SendDevtokenTransaction(method string, args ...interface{}) (string, error) {
+	parsed, err := DevtokenABI()
+	if err != nil {
+		return "", err
+	}
+	data, err := parsed.Pack(method, args...)
+	if err != nil {
+		return "", err
+	}
+	from := common.HexToAddress(TestAddress)
+	rlp, err := CreateRLPString(SmartContractAddress, from, *big.NewInt(0), data)
+	if err != nil {
+		return "", err
+	}
+	hash, err := SendRawTransaction(rlp)
+	if err != nil {
+		GetNonceManager(from).Resync()
+		return "", err
+	}
+	return hash, nil
+}
+
+// ToEthLog converts a log returned by the RPC into the go-ethereum format used by the contract bindings
+func ToEthLog(log TransactionLog) (ethtypes.Log, error) {
+	blockNumber, err := hexutil.DecodeUint64(log.BlockNumber)
+	if err != nil {
+		return ethtypes.Log{}, fmt.Errorf("blockNumber: %w", err)
+	}
+	txIndex, err := hexutil.DecodeUint64(log.TransactionIndex)
+	if err != nil {
+		return ethtypes.Log{}, fmt.Errorf("transactionIndex: %w", err)
+	}
+	logIndex, err := hexutil.DecodeUint64(log.LogIndex)
+	if err != nil {
+		return ethtypes.Log{}, fmt.Errorf("logIndex: %w", err)
+	}
+	data, err := hexutil.Decode(log.Data)
+	if err != nil {
+		return ethtypes.Log{}, fmt.Errorf("data: %w", err)
+	}
+	topics := make([]common.Hash, 0, len(log.Topics))
+	for _, topic := range log.Topics {
+		topics = append(topics, common.HexToHash(topic))
+	}
+	return ethtypes.Log{
+		Address:     common.HexToAddress(log.Address),
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: blockNumber,
+		TxHash:      common.HexToHash(log.TransactionHash),
+		TxIndex:     uint(txIndex),
+		BlockHash:   common.HexToHash(log.BlockHash),
+		Index:       uint(logIndex),
+		Removed:     log.Removed,
+	}, nil
+}
+
+// DecodeDevtokenEvent decodes a Devtoken log into the matching binding event
+// The result is a *devtoken.DevtokenTransfer, *devtoken.DevtokenApproval, *devtoken.DevtokenStaked or *devtoken.DevtokenOwnershipTransferred
+func DecodeDevtokenEvent(log TransactionLog) (interface{}, error) {
+	ethLog, err := ToEthLog(log)
+	if err != nil {
+		return nil, err
+	}
+	if len(ethLog.Topics) == 0 {
+		return nil, errors.New("log has no topics")
+	}
+	parsed, err := DevtokenABI()
+	if err != nil {
+		return nil, err
+	}
+	event, err := parsed.EventByID(ethLog.Topics[0])
+	if err != nil {
+		return nil, err
+	}
+	switch event.Name {
+	case "Transfer":
+		return deployedToken.ParseTransfer(ethLog)
+	case "Approval":
+		return deployedToken.ParseApproval(ethLog)
+	case "Staked":
+		return deployedToken.ParseStaked(ethLog)
+	case "OwnershipTransferred":
+		return deployedToken.ParseOwnershipTransferred(ethLog)
+	}
+	return nil, fmt.Errorf("no decoder for event %s", event.Name)
+}
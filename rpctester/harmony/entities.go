@@ -6,17 +6,22 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
+// Transaction is a transaction as returned by the explorer API
 type Transaction struct {
-	ID        string  `json:"id"`
-	Timestamp string  `json:"timestamp"`
-	From      string  `json:"from"`
-	To        string  `json:"to"`
-	Value     big.Int `json:"value"`
-	Bytes     string  `json:"bytes"`
-	Data      string  `json:"data"`
-	Type      string  `json:"type"`
+	ID        string      `json:"id"`
+	Timestamp string      `json:"timestamp"`
+	From      string      `json:"from"`
+	To        string      `json:"to"`
+	Value     ExplorerBig `json:"value"`
+	Bytes     string      `json:"bytes"`
+	Data      string      `json:"data"`
+	GasFee    ExplorerBig `json:"gasFee"`
+	FromShard uint32      `json:"fromShard"`
+	ToShard   uint32      `json:"toShard"`
+	Type      string      `json:"type"`
 }
 
 type Filter struct {
@@ -26,6 +31,14 @@ type Filter struct {
 	Topics    []string `json:"topics,omitempty"`
 }
 
+// LogFilter is a Filter that supports wildcard and OR topics
+type LogFilter struct {
+	FromBlock string    `json:"fromBlock,omitempty"`
+	ToBlock   string    `json:"toBlock,omitempty"`
+	Address   string    `json:"address,omitempty"`
+	Topics    LogTopics `json:"topics,omitempty"`
+}
+
 type FilterChange struct {
 	LogIndex         string   `json:"logIndex"`
 	BlockNumber      string   `json:"blockNumber"`
@@ -61,6 +74,45 @@ type Reciept struct {
 	Value       big.Int `json:"value"`
 }
 
+type RecieptV1 struct {
+	BlockHash   string `json:"blockHash"`
+	BlockNumber string `json:"blockNumber"`
+	Hash        string `json:"hash"`
+	From        string `json:"from"`
+	To          string `json:"to"`
+	ShardID     int    `json:"shardID"`
+	ToShardID   int    `json:"toShardID"`
+	Value       string `json:"value"`
+}
+
+// PendingCXReceipt is the proof of cross shard receipts waiting to be processed on the destination shard
+type PendingCXReceipt struct {
+	Receipts     []CXReceipt     `json:"Receipts"`
+	MerkleProof  CXMerkleProof   `json:"MerkleProof"`
+	Header       json.RawMessage `json:"Header"`
+	CommitSig    string          `json:"CommitSig"`
+	CommitBitmap string          `json:"CommitBitmap"`
+}
+
+// CXReceipt is the raw cross shard receipt, it is not formatted by the RPC
+type CXReceipt struct {
+	TxHash    string  `json:"TxHash"`
+	From      string  `json:"From"`
+	To        string  `json:"To"`
+	ShardID   uint32  `json:"ShardID"`
+	ToShardID uint32  `json:"ToShardID"`
+	Amount    big.Int `json:"Amount"`
+}
+
+type CXMerkleProof struct {
+	BlockNum      big.Int  `json:"BlockNum"`
+	BlockHash     string   `json:"BlockHash"`
+	ShardID       uint32   `json:"ShardID"`
+	CXReceiptHash string   `json:"CXReceiptHash"`
+	ShardIDs      []uint32 `json:"ShardIDs"`
+	CXShardHashes []string `json:"CXShardHashes"`
+}
+
 type TransactionByHashV1 struct {
 	Hash             string `json:"hash"`
 	Nonce            string `json:"nonce"`
@@ -115,6 +167,29 @@ type TransactionHistoryV2 struct {
 	Transactions []TransactionByHashV2 `json:"transactions"`
 }
 
+type StakingTransactionHistoryV1 struct {
+	StakingTransactions []StakingTransactionV1 `json:"staking_transactions"`
+}
+
+type StakingTransactionHistoryV2 struct {
+	StakingTransactions []StakingTransactionByHashV2 `json:"staking_transactions"`
+}
+
+// StakingTransactionByHashV2 is a staking transaction as returned by the V2 API, numbers are not hex encoded
+type StakingTransactionByHashV2 struct {
+	Hash             string          `json:"hash"`
+	Nonce            big.Int         `json:"nonce"`
+	BlockHash        string          `json:"blockHash"`
+	BlockNumber      big.Int         `json:"blockNumber"`
+	TransactionIndex big.Int         `json:"transactionIndex"`
+	Timestamp        int64           `json:"timestamp"`
+	From             string          `json:"from"`
+	GasPrice         big.Int         `json:"gasPrice"`
+	Gas              big.Int         `json:"gas"`
+	Type             string          `json:"type"`
+	Msg              json.RawMessage `json:"msg"`
+}
+
 type TransactionReceipt_V1 struct {
 	BlockHash         string           `json:"blockHash"`
 	BlockNumber       string           `json:"blockNumber"`
@@ -197,6 +272,13 @@ type StakingTransactionV2 struct {
 	Msg  json.RawMessage `json:"msg"`
 }
 
+// BlockArgs selects what is included in the blocks returned by getBlockByNumber and getBlocks
+type BlockArgs struct {
+	FullTx      bool `json:"fullTx"`
+	InclStaking bool `json:"inclStaking"`
+	WithSigners bool `json:"withSigners"`
+}
+
 type BlockV1 struct {
 	Number             string                 `json:"number"`
 	Hash               string                 `json:"hash"`
@@ -238,13 +320,14 @@ type BlockV2 struct {
 	Uncles             []string               `json:"uncles"`
 }
 
+// RpcCallArgs are the call arguments of hmy_call and hmy_estimateGas, the value has to be sent as a hex string
 type RpcCallArgs struct {
-	From     string `json:"from,omitempty"`
-	To       string `json:"to"`
-	Gas      int64  `json:"gas,omitempty"`
-	GasPrice int64  `json:"gasPrice,omitempty"`
-	Value    int64  `json:"value,omitempty"`
-	Data     string `json:"data,omitempty"`
+	From     string       `json:"from,omitempty"`
+	To       string       `json:"to"`
+	Gas      int64        `json:"gas,omitempty"`
+	GasPrice int64        `json:"gasPrice,omitempty"`
+	Value    *hexutil.Big `json:"value,omitempty"`
+	Data     string       `json:"data,omitempty"`
 }
 
 type NetworkHeader struct {
@@ -280,6 +363,9 @@ type ValidatorInfo struct {
 	CurrentEpochSign        CurrentEpochSign          `json:"current-epoch-signing-percent"`
 	CurrentEpochVotingPower []CurrentEpochVotingPower `json:"current-epoch-voting-power"`
 	Validator               Validator                 `json:"validator"`
+	TotalDelegation         big.Int                   `json:"total-delegation"`
+	CurrentlyInCommittee    bool                      `json:"currently-in-committee"`
+	EPoSStatus              string                    `json:"epos-status"`
 }
 
 type CurrentEpochSign struct {
@@ -325,7 +411,7 @@ type ValidatorAvailability struct {
 type Delegation struct {
 	Amount        big.Int           `json:"amount"`
 	DelegatorAddr string            `json:"delegator-address"`
-	Reward        int64             `json:"reward"`
+	Reward        big.Int           `json:"reward"`
 	Undelegations []RPCUndelegation `json:"undelegations"`
 }
 type UtilityMetrics struct {
@@ -385,16 +471,18 @@ type SimpleValidatorV2 struct {
 	Balance big.Int `json:"balance"`
 }
 
+// TraceBlock is a parity style trace returned by trace_block and trace_transaction
 type TraceBlock struct {
 	BlockNumber         int64       `json:"blockNumber"`
 	BlockHash           string      `json:"blockHash"`
 	TransactionHash     string      `json:"transactionHash"`
 	TransactionPosition int         `json:"transactionPosition"`
 	Subtraces           int         `json:"subtraces"`
-	TraceAddress        []string    `json:"traceAddress"`
-	Type                string      `json:"call"`
+	TraceAddress        []int       `json:"traceAddress"`
+	Type                string      `json:"type"`
 	Action              TraceAction `json:"action"`
-	Result              TraceResult `json:"traceResult"`
+	Result              TraceResult `json:"result"`
+	Error               string      `json:"error,omitempty"`
 }
 
 type TraceAction struct {
@@ -404,9 +492,76 @@ type TraceAction struct {
 	Gas      string `json:"gas"`
 	From     string `json:"from"`
 	Input    string `json:"input"`
+	// Init is the creation code of create traces
+	Init string `json:"init,omitempty"`
 }
 
 type TraceResult struct {
 	Output  string `json:"output"`
 	GasUsed string `json:"gasUsed"`
+	// Address and Code are set on create traces
+	Address string `json:"address,omitempty"`
+	Code    string `json:"code,omitempty"`
+}
+
+// CallFrame is a call returned by debug_traceTransaction using the callTracer
+type CallFrame struct {
+	Type    string      `json:"type"`
+	From    string      `json:"from"`
+	To      string      `json:"to"`
+	Value   string      `json:"value"`
+	Gas     string      `json:"gas"`
+	GasUsed string      `json:"gasUsed"`
+	Input   string      `json:"input"`
+	Output  string      `json:"output"`
+	Error   string      `json:"error,omitempty"`
+	Calls   []CallFrame `json:"calls,omitempty"`
+}
+
+// TraceConfig selects the tracer used by debug_traceTransaction
+type TraceConfig struct {
+	Tracer  string `json:"tracer,omitempty"`
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// EthBlock is a block as returned by the eth namespace
+type EthBlock struct {
+	Number       hexutil.Uint64    `json:"number"`
+	Hash         common.Hash       `json:"hash"`
+	ParentHash   common.Hash       `json:"parentHash"`
+	Miner        common.Address    `json:"miner"`
+	Timestamp    hexutil.Uint64    `json:"timestamp"`
+	GasLimit     hexutil.Uint64    `json:"gasLimit"`
+	GasUsed      hexutil.Uint64    `json:"gasUsed"`
+	Transactions []json.RawMessage `json:"transactions"`
+}
+
+// EthTransaction is a transaction as returned by the eth namespace
+type EthTransaction struct {
+	Hash             common.Hash     `json:"hash"`
+	BlockHash        *common.Hash    `json:"blockHash"`
+	BlockNumber      *hexutil.Big    `json:"blockNumber"`
+	TransactionIndex *hexutil.Uint64 `json:"transactionIndex"`
+	From             common.Address  `json:"from"`
+	To               *common.Address `json:"to"`
+	Value            hexutil.Big     `json:"value"`
+	Nonce            hexutil.Uint64  `json:"nonce"`
+	Gas              hexutil.Uint64  `json:"gas"`
+	GasPrice         hexutil.Big     `json:"gasPrice"`
+	Input            hexutil.Bytes   `json:"input"`
+}
+
+// EthReceipt is a transaction receipt as returned by the eth namespace
+type EthReceipt struct {
+	TransactionHash   common.Hash       `json:"transactionHash"`
+	TransactionIndex  hexutil.Uint64    `json:"transactionIndex"`
+	BlockHash         common.Hash       `json:"blockHash"`
+	BlockNumber       hexutil.Big       `json:"blockNumber"`
+	From              common.Address    `json:"from"`
+	To                *common.Address   `json:"to"`
+	GasUsed           hexutil.Uint64    `json:"gasUsed"`
+	CumulativeGasUsed hexutil.Uint64    `json:"cumulativeGasUsed"`
+	ContractAddress   *common.Address   `json:"contractAddress"`
+	Status            hexutil.Uint64    `json:"status"`
+	Logs              []json.RawMessage `json:"logs"`
 }
@@ -0,0 +1,449 @@
+package harmony
+
+import (
+	"context"
+	"fmt"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"sort"
+	"strings"
+	"time"
+)
+
+const METRIC_epochTransition = "epoch_transition"
+
+// SuperCommittees is the result of getSuperCommittees, the committees of the previous and the current epoch
+type SuperCommittees struct {
+	Previous CommitteeRegistry `json:"previous"`
+	Current  CommitteeRegistry `json:"current"`
+}
+
+// CommitteeRegistry holds the committee of every shard in one epoch, keyed by shard-<id>
+type CommitteeRegistry struct {
+	Deciders      map[string]QuorumDecider `json:"quorum-deciders"`
+	ExternalCount int                      `json:"external-slot-count"`
+	MedianStake   StakeDec                 `json:"epos-median-stake"`
+}
+
+// QuorumDecider is the committee of one shard and how its voting power is split
+type QuorumDecider struct {
+	Policy              string            `json:"policy"`
+	Count               int               `json:"count"`
+	Externals           int               `json:"external-validator-slot-count"`
+	Members             []CommitteeMember `json:"committee-members"`
+	HmyVotingPower      StakeDec          `json:"hmy-voting-power"`
+	StakedVotingPower   StakeDec          `json:"staked-voting-power"`
+	TotalRawStake       StakeDec          `json:"total-raw-stake"`
+	TotalEffectiveStake StakeDec          `json:"total-effective-stake"`
+}
+
+// CommitteeMember is one BLS key in a committee, the stakes are only set for external validators
+type CommitteeMember struct {
+	IsHarmonySlot  bool     `json:"is-harmony-slot"`
+	EarningAccount string   `json:"earning-account"`
+	BLSPublicKey   string   `json:"bls-public-key"`
+	RawPercent     StakeDec `json:"voting-power-unnormalized"`
+	VotingPower    StakeDec `json:"voting-power-%"`
+	EffectiveStake StakeDec `json:"effective-stake"`
+	RawStake       StakeDec `json:"raw-stake"`
+}
+
+// Shard returns the committee of shardID and whether the registry holds it
+func (r CommitteeRegistry) Shard(shardID int64) (QuorumDecider, bool) {
+	decider, ok := r.Deciders[fmt.Sprintf("shard-%d", shardID)]
+	return decider, ok
+}
+
+// Validate verifies that the registry holds committees and that every committee counts its own members
+func (r CommitteeRegistry) Validate() error {
+	if len(r.Deciders) == 0 {
+		return fmt.Errorf("the registry holds no committees")
+	}
+	for shard, decider := range r.Deciders {
+		if decider.Count != len(decider.Members) {
+			return fmt.Errorf("%s counts %d members but lists %d", shard, decider.Count, len(decider.Members))
+		}
+		externals := 0
+		for _, member := range decider.Members {
+			if !member.IsHarmonySlot {
+				externals++
+			}
+		}
+		if decider.Externals != externals {
+			return fmt.Errorf("%s counts %d external slots but lists %d", shard, decider.Externals, externals)
+		}
+	}
+	return nil
+}
+
+// Elected returns the sorted earning accounts of the external validators in every shard
+func (r CommitteeRegistry) Elected() []string {
+	set := map[string]bool{}
+	for _, decider := range r.Deciders {
+		for _, member := range decider.Members {
+			if !member.IsHarmonySlot {
+				set[normalizeAddress(member.EarningAccount)] = true
+			}
+		}
+	}
+	return sortedKeys(set)
+}
+
+// Keys returns the BLS keys of the committee
+func (d QuorumDecider) Keys() map[string]bool {
+	keys := make(map[string]bool, len(d.Members))
+	for _, member := range d.Members {
+		keys[strings.ToLower(member.BLSPublicKey)] = true
+	}
+	return keys
+}
+
+// EarningAccounts returns the accounts that the committee members earn to, block signers are reported by them
+func (d QuorumDecider) EarningAccounts() map[string]bool {
+	accounts := make(map[string]bool, len(d.Members))
+	for _, member := range d.Members {
+		accounts[normalizeAddress(member.EarningAccount)] = true
+	}
+	return accounts
+}
+
+// GetSuperCommittees returns hmyv2_getSuperCommittees, only the beacon shard serves it
+func GetSuperCommittees() (*SuperCommittees, error) {
+	var result SuperCommittees
+	if _, err := CallMethod(URL, methods.METHOD_protocol_V2_getSuperCommittees, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetLatestHeader returns hmy_latestHeader
+func GetLatestHeader() (*NetworkHeader, error) {
+	var header NetworkHeader
+	if _, err := CallMethod(URL, methods.METHOD_protocol_lastestHeader, nil, &header); err != nil {
+		return nil, err
+	}
+	return &header, nil
+}
+
+// GetHeaderByNumber returns the header of block n
+func GetHeaderByNumber(n int64) (*NetworkHeader, error) {
+	var header NetworkHeader
+	if _, err := CallMethod(URL, methods.METHOD_protocol_V2_getHeaderByNumber, []interface{}{n}, &header); err != nil {
+		return nil, err
+	}
+	if header.BlockHash == "" {
+		return nil, fmt.Errorf("header %d not found", n)
+	}
+	return &header, nil
+}
+
+// EpochLastBlock returns the last block of epoch according to the shard schedule
+func EpochLastBlock(epoch int64) (int64, error) {
+	var block int64
+	_, err := CallMethod(URL, methods.METHOD_protocol_epochLastBlock, []interface{}{epoch}, &block)
+	return block, err
+}
+
+// IsLastBlock returns whether block n ends an epoch according to the shard schedule
+func IsLastBlock(n int64) (bool, error) {
+	var last bool
+	_, err := CallMethod(URL, methods.METHOD_protocol_isLastBlock, []interface{}{n}, &last)
+	return last, err
+}
+
+// EpochSnapshot is what the chain reports about the committees of one epoch
+type EpochSnapshot struct {
+	Header     NetworkHeader
+	Committees *SuperCommittees
+	Elected    []string
+}
+
+// TakeEpochSnapshot fetches the committees and elected validators of the current epoch
+// The header is fetched before and after, if the epoch changed in between the snapshot is taken again
+func TakeEpochSnapshot() (*EpochSnapshot, error) {
+	for attempt := 0; attempt < 3; attempt++ {
+		header, err := GetLatestHeader()
+		if err != nil {
+			return nil, err
+		}
+		committees, err := GetSuperCommittees()
+		if err != nil {
+			return nil, err
+		}
+		elected, err := GetAddressList(methods.METHOD_staking_V2_getElectedValidatorAddresses)
+		if err != nil {
+			return nil, err
+		}
+		after, err := GetLatestHeader()
+		if err != nil {
+			return nil, err
+		}
+		if after.Epoch == header.Epoch {
+			for i := range elected {
+				elected[i] = normalizeAddress(elected[i])
+			}
+			sort.Strings(elected)
+			return &EpochSnapshot{Header: *header, Committees: committees, Elected: elected}, nil
+		}
+	}
+	return nil, fmt.Errorf("the epoch kept changing while taking a snapshot")
+}
+
+// EpochTransition is an epoch boundary observed on the chain
+type EpochTransition struct {
+	// Epoch is the epoch that ended
+	Epoch int64
+	// LastBlock is the last block of Epoch, found from the epoch of the headers
+	LastBlock int64
+	Before    *EpochSnapshot
+	After     *EpochSnapshot
+}
+
+// WatchEpochTransition polls the latest header every interval until the current epoch ends or ctx is done
+// Once the epoch changed, it waits for the first block of the new epoch to be signed before taking the second snapshot
+func WatchEpochTransition(ctx context.Context, interval time.Duration) (*EpochTransition, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("the poll interval has to be positive, got %s", interval)
+	}
+	before, err := TakeEpochSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	epoch := before.Header.Epoch
+	lastSeen := before.Header.BlockNumber
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var head *NetworkHeader
+	for head == nil {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("epoch %d did not end by block %d: %w", epoch, lastSeen, ctx.Err())
+		case <-ticker.C:
+		}
+		header, err := GetLatestHeader()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case header.Epoch == epoch:
+			lastSeen = header.BlockNumber
+		case header.Epoch == epoch+1:
+			head = header
+		default:
+			return nil, fmt.Errorf("the epoch moved from %d to %d between block %d and %d", epoch, header.Epoch, lastSeen, header.BlockNumber)
+		}
+	}
+
+	lastBlock, err := findEpochLastBlock(epoch, lastSeen, head.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	// getBlockSigners reads the signatures of block n from block n+1, the first block of the new epoch needs a child
+	for head.BlockNumber < lastBlock+2 {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for block %d: %w", lastBlock+2, ctx.Err())
+		case <-ticker.C:
+		}
+		if head, err = GetLatestHeader(); err != nil {
+			return nil, err
+		}
+	}
+	after, err := TakeEpochSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	if after.Header.Epoch != epoch+1 {
+		return nil, fmt.Errorf("epoch %d ended before the snapshot of epoch %d was taken", epoch+1, epoch+1)
+	}
+	return &EpochTransition{Epoch: epoch, LastBlock: lastBlock, Before: before, After: after}, nil
+}
+
+// findEpochLastBlock searches for the last block of epoch, from is in epoch and to is in the next epoch
+func findEpochLastBlock(epoch int64, from, to int64) (int64, error) {
+	for to-from > 1 {
+		mid := from + (to-from)/2
+		header, err := GetHeaderByNumber(mid)
+		if err != nil {
+			return 0, err
+		}
+		if header.Epoch <= epoch {
+			from = mid
+		} else {
+			to = mid
+		}
+	}
+	return from, nil
+}
+
+// EpochCheck is the outcome of one check of an epoch transition
+type EpochCheck struct {
+	Name   string `json:"name"`
+	Method string `json:"method"`
+	Error  string `json:"error,omitempty"`
+}
+
+// epochChecker is one check of CheckEpochTransition
+type epochChecker struct {
+	name   string
+	method string
+	check  func(*EpochTransition) error
+}
+
+var epochCheckers = []epochChecker{
+	{"epoch_last_block", methods.METHOD_protocol_epochLastBlock, checkEpochLastBlock},
+	{"is_last_block", methods.METHOD_protocol_isLastBlock, checkIsLastBlock},
+	{"super_committees", methods.METHOD_protocol_V2_getSuperCommittees, checkSuperCommittees},
+	{"block_signers", methods.METHOD_staking_V2_getBlockSigners, checkBlockSigners},
+	{"elected_validators", methods.METHOD_staking_V2_getElectedValidatorAddresses, checkElectedValidators},
+}
+
+// run performs the check on the transition
+func (c epochChecker) run(tr *EpochTransition) EpochCheck {
+	result := EpochCheck{Name: c.name, Method: c.method}
+	if err := c.check(tr); err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// CheckEpochTransition verifies what the RPC reports about the transition against the observed last block
+func CheckEpochTransition(tr *EpochTransition) []EpochCheck {
+	results := make([]EpochCheck, 0, len(epochCheckers))
+	for _, c := range epochCheckers {
+		results = append(results, c.run(tr))
+	}
+	return results
+}
+
+func checkEpochLastBlock(tr *EpochTransition) error {
+	last, err := EpochLastBlock(tr.Epoch)
+	if err != nil {
+		return err
+	}
+	if last != tr.LastBlock {
+		return fmt.Errorf("epochLastBlock reports block %d for epoch %d, the chain moved to epoch %d after block %d", last, tr.Epoch, tr.Epoch+1, tr.LastBlock)
+	}
+	return nil
+}
+
+func checkIsLastBlock(tr *EpochTransition) error {
+	for _, n := range []int64{tr.LastBlock - 1, tr.LastBlock, tr.LastBlock + 1} {
+		last, err := IsLastBlock(n)
+		if err != nil {
+			return err
+		}
+		if want := n == tr.LastBlock; last != want {
+			return fmt.Errorf("isLastBlock reports %t for block %d, the last block of epoch %d is %d", last, n, tr.Epoch, tr.LastBlock)
+		}
+	}
+	return nil
+}
+
+// checkSuperCommittees verifies that the committees reported as current before the transition are reported as previous after it
+func checkSuperCommittees(tr *EpochTransition) error {
+	before, after := tr.Before.Committees.Current, tr.After.Committees.Previous
+	if err := tr.After.Committees.Current.Validate(); err != nil {
+		return fmt.Errorf("the committees of epoch %d: %w", tr.Epoch+1, err)
+	}
+	if len(before.Deciders) != len(after.Deciders) {
+		return fmt.Errorf("epoch %d had %d committees but %d are reported as previous", tr.Epoch, len(before.Deciders), len(after.Deciders))
+	}
+	for shard, decider := range before.Deciders {
+		previous, ok := after.Deciders[shard]
+		if !ok {
+			return fmt.Errorf("the previous committees do not hold %s", shard)
+		}
+		if joined, left := keyChanges(decider.Keys(), previous.Keys()); len(joined)+len(left) > 0 {
+			return fmt.Errorf("the previous committee of %s differs from the committee of epoch %d, %d keys joined and %d left", shard, tr.Epoch, len(joined), len(left))
+		}
+	}
+	return nil
+}
+
+// checkBlockSigners verifies that the last block of the old epoch is signed by its committee and the first block
+// of the new epoch by the new committee, V1 and V2 have to report the same signers
+func checkBlockSigners(tr *EpochTransition) error {
+	shardID := tr.After.Header.ShardID
+	for _, block := range []struct {
+		n         int64
+		epoch     int64
+		committee CommitteeRegistry
+	}{
+		{tr.LastBlock, tr.Epoch, tr.Before.Committees.Current},
+		{tr.LastBlock + 1, tr.Epoch + 1, tr.After.Committees.Current},
+	} {
+		decider, ok := block.committee.Shard(shardID)
+		if !ok {
+			return fmt.Errorf("epoch %d has no committee for shard %d", block.epoch, shardID)
+		}
+		var v1, v2 []string
+		if _, err := CallMethod(URL, methods.METHOD_staking_V1_getBlockSigners, []interface{}{fmt.Sprintf("0x%x", block.n)}, &v1); err != nil {
+			return err
+		}
+		if _, err := CallMethod(URL, methods.METHOD_staking_V2_getBlockSigners, []interface{}{block.n}, &v2); err != nil {
+			return err
+		}
+		signersV1, signers := addressSet(v1), addressSet(v2)
+		if len(signers) == 0 {
+			return fmt.Errorf("block %d has no signers", block.n)
+		}
+		if joined, left := keyChanges(signersV1, signers); len(joined)+len(left) > 0 {
+			return fmt.Errorf("block %d has %d signers in V1 and %d in V2, %d differ", block.n, len(signersV1), len(signers), len(joined)+len(left))
+		}
+		accounts := decider.EarningAccounts()
+		for signer := range signers {
+			if !accounts[signer] {
+				return fmt.Errorf("block %d is signed by %s, which is not in the committee of epoch %d", block.n, signer, block.epoch)
+			}
+		}
+	}
+	return nil
+}
+
+// checkElectedValidators verifies that the elected validators are the external validators of the committees of each epoch
+func checkElectedValidators(tr *EpochTransition) error {
+	for _, snapshot := range []*EpochSnapshot{tr.Before, tr.After} {
+		committee := addressSet(snapshot.Committees.Current.Elected())
+		elected := addressSet(snapshot.Elected)
+		if joined, left := keyChanges(committee, elected); len(joined)+len(left) > 0 {
+			return fmt.Errorf("epoch %d elected %d validators that are not in its committees and left out %d that are", snapshot.Header.Epoch, len(joined), len(left))
+		}
+	}
+	return nil
+}
+
+// ElectionChanges returns the validators elected in the new epoch but not in the old one, and those no longer elected
+func (tr *EpochTransition) ElectionChanges() (elected, unelected []string) {
+	return keyChanges(addressSet(tr.Before.Elected), addressSet(tr.After.Elected))
+}
+
+// CommitteeChanges returns the BLS keys that joined and left the committee of shardID in the transition
+func (tr *EpochTransition) CommitteeChanges(shardID int64) (joined, left []string) {
+	before, _ := tr.Before.Committees.Current.Shard(shardID)
+	after, _ := tr.After.Committees.Current.Shard(shardID)
+	return keyChanges(before.Keys(), after.Keys())
+}
+
+// keyChanges returns the sorted keys that are only in after and those that are only in before
+func keyChanges(before, after map[string]bool) (joined, left []string) {
+	only := func(a, b map[string]bool) []string {
+		diff := map[string]bool{}
+		for key := range a {
+			if !b[key] {
+				diff[key] = true
+			}
+		}
+		return sortedKeys(diff)
+	}
+	return only(after, before), only(before, after)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,65 @@
+package harmony
+
+import (
+	"context"
+	"errors"
+	"percybolmer/rpc-shard-testing/rpctester/crypto"
+	"testing"
+	"time"
+)
+
+const (
+	// maxEpochWaitBlocks is the most blocks test_epochTransition waits for the current epoch to end
+	maxEpochWaitBlocks = 100
+	// epochWaitTimeout bounds the wait for the epoch to end, long enough for maxEpochWaitBlocks at 2 second blocks
+	epochWaitTimeout = 6 * time.Minute
+	// epochPollInterval is the time between every latestHeader poll while waiting for the epoch to end
+	epochPollInterval = 2 * time.Second
+)
+
+// test_epochTransition waits for the current epoch to end and checks the transition against the observed last block
+// It only runs when listed in LONG_TESTS, and is skipped when the epoch ends too far away, such as on mainnet
+func test_epochTransition(t *testing.T) {
+	requireLongTest(t, "epochTransition")
+	if shardID, err := crypto.GetShardID(); err == nil && shardID != 0 {
+		t.Skip("Epoch and committee information is only served by the beacon shard")
+	}
+	header, err := GetLatestHeader()
+	if err != nil {
+		failMetric(t, METRIC_epochTransition, err)
+		return
+	}
+	lastBlock, err := EpochLastBlock(header.Epoch)
+	if err != nil {
+		failMetric(t, METRIC_epochTransition, err)
+		return
+	}
+	if remaining := lastBlock - header.BlockNumber; remaining > maxEpochWaitBlocks {
+		t.Skipf("epoch %d ends in %d blocks, more than the %d to wait for", header.Epoch, remaining, maxEpochWaitBlocks)
+	}
+
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), epochWaitTimeout)
+	defer cancel()
+	transition, err := WatchEpochTransition(ctx, epochPollInterval)
+	if err != nil {
+		failMetric(t, METRIC_epochTransition, err)
+		return
+	}
+	t.Logf("waited %s for the epoch to end", time.Since(start))
+	elected, unelected := transition.ElectionChanges()
+	joined, left := transition.CommitteeChanges(transition.After.Header.ShardID)
+	t.Logf("epoch %d ended at block %d, %d validators elected and %d unelected, %d keys joined and %d left shard %d",
+		transition.Epoch, transition.LastBlock, len(elected), len(unelected), len(joined), len(left), transition.After.Header.ShardID)
+
+	for _, checker := range epochCheckers {
+		t.Run(checker.name, func(t *testing.T) {
+			start := time.Now()
+			if check := checker.run(transition); check.Error != "" {
+				failMetric(t, METRIC_epochTransition, errors.New(check.Error))
+				return
+			}
+			passMetric(t, METRIC_epochTransition, start)
+		})
+	}
+}
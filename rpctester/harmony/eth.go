@@ -0,0 +1,58 @@
+package harmony
+
+import (
+	"context"
+	"math/big"
+	"percybolmer/rpc-shard-testing/rpctester/crypto"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+const METRIC_ethCrossNamespace = "eth_cross_namespace"
+
+// ethTransferGasLimit is the intrinsic gas of a transfer without data
+const ethTransferGasLimit = 21000
+
+// EthBlockNumber formats n as a block number param for the eth namespace
+func EthBlockNumber(n int64) string {
+	return hexutil.EncodeUint64(uint64(n))
+}
+
+// CreateEthTransfer generates RLP for an Ethereum transaction sent from and signed by signer
+// eth_sendRawTransaction only decodes Ethereum transactions, they have no shard fields and are signed for the eth chain ID in CHAIN_ID
+// The nonce used is returned, it should be released with the NonceManager of the signer if the transaction is rejected
+func CreateEthTransfer(signer crypto.Signer, to common.Address, amount *big.Int) (string, uint64, error) {
+	chainID, err := crypto.GetChainID()
+	if err != nil {
+		return "", 0, err
+	}
+	gasPrice, err := ethClient.SuggestGasPrice(context.Background())
+	if err != nil {
+		return "", 0, err
+	}
+	nonces := GetNonceManager(signer.Address())
+	nonce, err := nonces.Next()
+	if err != nil {
+		return "", 0, err
+	}
+	tx := ethtypes.NewTransaction(nonce, to, amount, ethTransferGasLimit, gasPrice, nil)
+	ethSigner := ethtypes.NewEIP155Signer(chainID)
+	sig, err := signer.SignHash(ethSigner.Hash(tx).Bytes())
+	if err != nil {
+		nonces.Release(nonce)
+		return "", 0, err
+	}
+	signedTx, err := tx.WithSignature(ethSigner, sig)
+	if err != nil {
+		nonces.Release(nonce)
+		return "", 0, err
+	}
+	data, err := signedTx.MarshalBinary()
+	if err != nil {
+		nonces.Release(nonce)
+		return "", 0, err
+	}
+	return hexutil.Encode(data), nonce, nil
+}
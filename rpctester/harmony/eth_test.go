@@ -0,0 +1,484 @@
+package harmony
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"percybolmer/rpc-shard-testing/rpctester/crypto"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// test_EthMethods covers the eth, net and web3 namespaces used by EVM tooling
+func test_EthMethods(t *testing.T) {
+	t.Run("ethSanity", ts.test_ethSanity)
+	t.Run("ethCrossNamespace", ts.test_ethCrossNamespace)
+}
+
+// web3Sha3Input is "hello world", its keccak hash is computed locally to verify web3_sha3
+var web3Sha3Input = hexutil.Bytes("hello world")
+
+// test_ethSanity calls every eth, net and web3 method and validates the returned data type
+// The cases run in order, later cases use the transaction sent and the filters created by earlier ones
+func (ts *testSuite) test_ethSanity(t *testing.T) {
+	type testcase struct {
+		name              string
+		method            string
+		params            func() []interface{}
+		result            interface{}
+		expectedErrorCode int64
+		// after runs when the call succeeded, to wait for or store what later cases need
+		after func() error
+		// failed runs when the call failed, to give back what the case reserved
+		failed func()
+	}
+
+	parsed, err := DevtokenABI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	nameCall, err := parsed.Pack("name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	call := RpcCallArgs{
+		From: common.HexToAddress(TestAddress).Hex(),
+		To:   SmartContractAddress.Hex(),
+		Data: hexutil.Encode(nameCall),
+	}
+	// Transfer 0.001 ONE to ourself, the eth namespace only accepts Ethereum transactions
+	address := common.HexToAddress(TestAddress)
+	signer := crypto.GetSigner()
+	rlp, nonce, err := CreateEthTransfer(signer, address, big.NewInt(0).Div(ONE, big.NewInt(1000)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		txHash              common.Hash
+		receipt             *TransactionReceipt_V2
+		logFilterID         string
+		blockFilterID       string
+		pendingFilterID     string
+		noParams            = func() []interface{} { return []interface{}{} }
+		blockNumber         = func() string { return EthBlockNumber(receipt.BlockNumber) }
+		transactionIndex    = func() string { return hexutil.EncodeUint64(uint64(receipt.TransactionIndex)) }
+		contractLogsInBlock = func() LogFilter {
+			return LogFilter{FromBlock: blockNumber(), ToBlock: blockNumber(), Address: SmartContractAddress.Hex()}
+		}
+	)
+
+	testCases := []testcase{
+		{name: "chainId", method: methods.METHOD_eth_chainId, params: noParams, result: new(hexutil.Big)},
+		{name: "protocolVersion", method: methods.METHOD_eth_protocolVersion, params: noParams, result: new(json.RawMessage)},
+		{name: "syncing", method: methods.METHOD_eth_syncing, params: noParams, result: new(json.RawMessage)},
+		{name: "blockNumber", method: methods.METHOD_eth_blockNumber, params: noParams, result: new(hexutil.Uint64)},
+		{name: "gasPrice", method: methods.METHOD_eth_gasPrice, params: noParams, result: new(hexutil.Big)},
+		{
+			name:   "getBalance",
+			method: methods.METHOD_eth_getBalance,
+			params: func() []interface{} { return []interface{}{address, "latest"} },
+			result: new(hexutil.Big),
+		}, {
+			name:              "getBalance_missing_param",
+			method:            methods.METHOD_eth_getBalance,
+			params:            func() []interface{} { return []interface{}{address} },
+			result:            new(hexutil.Big),
+			expectedErrorCode: -32602,
+		}, {
+			name:   "getTransactionCount",
+			method: methods.METHOD_eth_getTransactionCount,
+			params: func() []interface{} { return []interface{}{address, "latest"} },
+			result: new(hexutil.Uint64),
+		}, {
+			name:   "getCode",
+			method: methods.METHOD_eth_getCode,
+			params: func() []interface{} { return []interface{}{SmartContractAddress, "latest"} },
+			result: new(hexutil.Bytes),
+		}, {
+			name:   "getStorageAt",
+			method: methods.METHOD_eth_getStorageAt,
+			params: func() []interface{} { return []interface{}{SmartContractAddress, "0x0", "latest"} },
+			result: new(hexutil.Bytes),
+		}, {
+			name:   "call",
+			method: methods.METHOD_eth_call,
+			params: func() []interface{} { return []interface{}{call, "latest"} },
+			result: new(hexutil.Bytes),
+		}, {
+			name:   "estimateGas",
+			method: methods.METHOD_eth_estimateGas,
+			params: func() []interface{} { return []interface{}{call} },
+			result: new(hexutil.Uint64),
+		}, {
+			name:   "sendRawTransaction",
+			method: methods.METHOD_eth_sendRawTransaction,
+			params: func() []interface{} { return []interface{}{rlp} },
+			result: &txHash,
+			failed: func() { GetNonceManager(signer.Address()).Release(nonce) },
+			after: func() error {
+				ts.LastEthTransactionHash = txHash.Hex()
+				ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+				defer cancel()
+				receipt, err = WaitForReceipt(ctx, txHash.Hex())
+				return err
+			},
+		}, {
+			name:   "getBlockByNumber",
+			method: methods.METHOD_eth_getBlockByNumber,
+			params: func() []interface{} { return []interface{}{blockNumber(), true} },
+			result: new(EthBlock),
+		}, {
+			name:              "getBlockByNumber_missing_param",
+			method:            methods.METHOD_eth_getBlockByNumber,
+			params:            func() []interface{} { return []interface{}{blockNumber()} },
+			result:            new(EthBlock),
+			expectedErrorCode: -32602,
+		}, {
+			name:   "getBlockByHash",
+			method: methods.METHOD_eth_getBlockByHash,
+			params: func() []interface{} { return []interface{}{receipt.BlockHash, false} },
+			result: new(EthBlock),
+		}, {
+			name:   "getBlockTransactionCountByNumber",
+			method: methods.METHOD_eth_getBlockTransactionCountByNumber,
+			params: func() []interface{} { return []interface{}{blockNumber()} },
+			result: new(hexutil.Uint64),
+		}, {
+			name:   "getBlockTransactionCountByHash",
+			method: methods.METHOD_eth_getBlockTransactionCountByHash,
+			params: func() []interface{} { return []interface{}{receipt.BlockHash} },
+			result: new(hexutil.Uint64),
+		}, {
+			name:   "getTransactionByHash",
+			method: methods.METHOD_eth_getTransactionByHash,
+			params: func() []interface{} { return []interface{}{txHash} },
+			result: new(EthTransaction),
+		}, {
+			name:   "getTransactionByBlockNumberAndIndex",
+			method: methods.METHOD_eth_getTransactionByBlockNumberAndIndex,
+			params: func() []interface{} { return []interface{}{blockNumber(), transactionIndex()} },
+			result: new(EthTransaction),
+		}, {
+			name:   "getTransactionByBlockHashAndIndex",
+			method: methods.METHOD_eth_getTransactionByBlockHashAndIndex,
+			params: func() []interface{} { return []interface{}{receipt.BlockHash, transactionIndex()} },
+			result: new(EthTransaction),
+		}, {
+			name:   "getTransactionReceipt",
+			method: methods.METHOD_eth_getTransactionReceipt,
+			params: func() []interface{} { return []interface{}{txHash} },
+			result: new(EthReceipt),
+		}, {
+			name:   "getLogs",
+			method: methods.METHOD_eth_getLogs,
+			params: func() []interface{} { return []interface{}{contractLogsInBlock()} },
+			result: new([]json.RawMessage),
+		}, {
+			name:   "newFilter",
+			method: methods.METHOD_eth_newFilter,
+			params: func() []interface{} { return []interface{}{contractLogsInBlock()} },
+			result: &logFilterID,
+		}, {
+			name:   "newBlockFilter",
+			method: methods.METHOD_eth_newBlockFilter,
+			params: noParams,
+			result: &blockFilterID,
+		}, {
+			name:   "newPendingTransactionFilter",
+			method: methods.METHOD_eth_newPendingTransactionFilter,
+			params: noParams,
+			result: &pendingFilterID,
+		}, {
+			name:   "getFilterLogs",
+			method: methods.METHOD_eth_getFilterLogs,
+			params: func() []interface{} { return []interface{}{logFilterID} },
+			result: new([]json.RawMessage),
+		}, {
+			name:   "getFilterChanges",
+			method: methods.METHOD_eth_getFilterChanges,
+			params: func() []interface{} { return []interface{}{blockFilterID} },
+			result: new([]json.RawMessage),
+		}, {
+			name:   "uninstallFilter",
+			method: methods.METHOD_eth_uninstallFilter,
+			params: func() []interface{} { return []interface{}{pendingFilterID} },
+			result: new(bool),
+		},
+		{name: "netVersion", method: methods.METHOD_net_version, params: noParams, result: new(string)},
+		{name: "netListening", method: methods.METHOD_net_listening, params: noParams, result: new(bool)},
+		{name: "clientVersion", method: methods.METHOD_web3_clientVersion, params: noParams, result: new(string)},
+		{
+			name:   "sha3",
+			method: methods.METHOD_web3_sha3,
+			params: func() []interface{} { return []interface{}{web3Sha3Input} },
+			result: new(hexutil.Bytes),
+		},
+	}
+
+	for _, tc := range testCases {
+		passed := t.Run(tc.name, func(t *testing.T) {
+			br := BaseRequest{
+				ID:      "1",
+				JsonRPC: "2.0",
+				Method:  tc.method,
+				Params:  tc.params(),
+			}
+			resp, err := callAndValidateDataType(t, tc.name, tc.expectedErrorCode, br, tc.result)
+			if err != nil {
+				if tc.failed != nil {
+					tc.failed()
+				}
+				t.Fatal(err)
+			}
+			if tc.after != nil {
+				if err := tc.after(); err != nil {
+					testMetrics = append(testMetrics, TestMetric{
+						Method:   tc.method,
+						Test:     tc.name,
+						Pass:     false,
+						Duration: resp.Duration,
+						Error:    err.Error(),
+						Params:   br.Params,
+					})
+					t.Fatal(err)
+				}
+			}
+			testMetrics = append(testMetrics, TestMetric{
+				Method:   tc.method,
+				Test:     tc.name,
+				Pass:     true,
+				Duration: resp.Duration,
+				Params:   br.Params,
+			})
+		})
+		if !passed && tc.after != nil {
+			t.Fatalf("cannot continue without %s", tc.name)
+		}
+	}
+}
+
+// test_ethCrossNamespace verifies that the eth namespace and the hmy namespaces agree on the same data
+// Account state is compared at a pinned block so that new blocks do not cause false failures
+func (ts *testSuite) test_ethCrossNamespace(t *testing.T) {
+	type testcase struct {
+		name  string
+		check func() error
+	}
+
+	latest, err := BlockNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pinned := EthBlockNumber(latest)
+	address := common.HexToAddress(TestAddress)
+
+	testCases := []testcase{
+		{
+			name: "chainId_matches_profile",
+			check: func() error {
+				expected, err := crypto.GetChainID()
+				if err != nil {
+					return err
+				}
+				var chainID hexutil.Big
+				if _, err := CallMethod(URL, methods.METHOD_eth_chainId, nil, &chainID); err != nil {
+					return err
+				}
+				if chainID.ToInt().Cmp(expected) != 0 {
+					return fmt.Errorf("eth_chainId is %s, the profile CHAIN_ID is %s", chainID.ToInt(), expected)
+				}
+				return nil
+			},
+		}, {
+			name: "blockNumber",
+			check: func() error {
+				var before, eth, after hexutil.Uint64
+				if _, err := CallMethod(URL, methods.METHOD_protocol_V1_blockNumber, nil, &before); err != nil {
+					return err
+				}
+				if _, err := CallMethod(URL, methods.METHOD_eth_blockNumber, nil, &eth); err != nil {
+					return err
+				}
+				if _, err := CallMethod(URL, methods.METHOD_protocol_V1_blockNumber, nil, &after); err != nil {
+					return err
+				}
+				if eth < before || eth > after {
+					return fmt.Errorf("eth_blockNumber %d is outside of hmy_blockNumber %d to %d", eth, before, after)
+				}
+				return nil
+			},
+		}, {
+			name: "getBalance",
+			check: func() error {
+				var eth, hmy hexutil.Big
+				if _, err := CallMethod(URL, methods.METHOD_eth_getBalance, []interface{}{address, pinned}, &eth); err != nil {
+					return err
+				}
+				if _, err := CallMethod(URL, methods.METHOD_V1_getBalanceByBlockNumber, []interface{}{TestAddress, pinned}, &hmy); err != nil {
+					return err
+				}
+				if eth.ToInt().Cmp(hmy.ToInt()) != 0 {
+					return fmt.Errorf("eth_getBalance %s and hmy_getBalanceByBlockNumber %s differ at block %d", eth.ToInt(), hmy.ToInt(), latest)
+				}
+				return nil
+			},
+		}, {
+			name: "getTransactionCount",
+			check: func() error {
+				var eth, hmy hexutil.Uint64
+				if _, err := CallMethod(URL, methods.METHOD_eth_getTransactionCount, []interface{}{address, pinned}, &eth); err != nil {
+					return err
+				}
+				if _, err := CallMethod(URL, methods.METHOD_V1_getTransactionCount, []interface{}{TestAddress, pinned}, &hmy); err != nil {
+					return err
+				}
+				if eth != hmy {
+					return fmt.Errorf("eth_getTransactionCount %d and hmy_getTransactionCount %d differ at block %d", eth, hmy, latest)
+				}
+				return nil
+			},
+		}, {
+			name: "getCode",
+			check: func() error {
+				var eth, hmy hexutil.Bytes
+				if _, err := CallMethod(URL, methods.METHOD_eth_getCode, []interface{}{SmartContractAddress, pinned}, &eth); err != nil {
+					return err
+				}
+				if _, err := CallMethod(URL, methods.METHOD_contract_getCode, []interface{}{SmartContractAddress, pinned}, &hmy); err != nil {
+					return err
+				}
+				if !bytes.Equal(eth, hmy) {
+					return fmt.Errorf("eth_getCode and hmy_getCode differ for %s", SmartContractAddress.Hex())
+				}
+				return nil
+			},
+		}, {
+			name: "gasPrice",
+			check: func() error {
+				var eth, hmy hexutil.Big
+				if _, err := CallMethod(URL, methods.METHOD_eth_gasPrice, nil, &eth); err != nil {
+					return err
+				}
+				if _, err := CallMethod(URL, methods.METHOD_protocol_V1_gasPrice, nil, &hmy); err != nil {
+					return err
+				}
+				if eth.ToInt().Cmp(hmy.ToInt()) != 0 {
+					return fmt.Errorf("eth_gasPrice %s and hmy_gasPrice %s differ", eth.ToInt(), hmy.ToInt())
+				}
+				return nil
+			},
+		}, {
+			name: "getBlockByNumber",
+			check: func() error {
+				var eth EthBlock
+				if _, err := CallMethod(URL, methods.METHOD_eth_getBlockByNumber, []interface{}{pinned, false}, &eth); err != nil {
+					return err
+				}
+				hmy, err := GetBlockByNumber(latest, BlockArgs{})
+				if err != nil {
+					return err
+				}
+				if eth.Hash != common.HexToHash(hmy.Hash) || eth.ParentHash != common.HexToHash(hmy.ParentHash) {
+					return fmt.Errorf("block %d has hash %s parent %s on eth and %s parent %s on hmy", latest, eth.Hash.Hex(), eth.ParentHash.Hex(), hmy.Hash, hmy.ParentHash)
+				}
+				if int64(eth.Number) != hmy.Number || int64(eth.Timestamp) != hmy.Timestamp {
+					return fmt.Errorf("block %d has number %d timestamp %d on eth and number %d timestamp %d on hmy", latest, eth.Number, eth.Timestamp, hmy.Number, hmy.Timestamp)
+				}
+				if len(eth.Transactions) != len(hmy.Transaction) {
+					return fmt.Errorf("block %d has %d transactions on eth and %d on hmy", latest, len(eth.Transactions), len(hmy.Transaction))
+				}
+				return nil
+			},
+		}, {
+			name: "getTransactionByHash",
+			check: func() error {
+				if ts.LastEthTransactionHash == "" {
+					return fmt.Errorf("no transaction was sent through %s", methods.METHOD_eth_sendRawTransaction)
+				}
+				var eth EthTransaction
+				if _, err := CallMethod(URL, methods.METHOD_eth_getTransactionByHash, []interface{}{ts.LastEthTransactionHash}, &eth); err != nil {
+					return err
+				}
+				hmy, err := GetTransactionByHash(ts.LastEthTransactionHash)
+				if err != nil {
+					return err
+				}
+				from, err := Bech32ToAddress(hmy.From)
+				if err != nil {
+					return err
+				}
+				if eth.From != from {
+					return fmt.Errorf("sender is %s on eth and %s on hmy", eth.From.Hex(), hmy.From)
+				}
+				if eth.BlockHash == nil || *eth.BlockHash != common.HexToHash(hmy.BlockHash) || eth.BlockNumber == nil || eth.BlockNumber.ToInt().Cmp(&hmy.BlockNumber) != 0 {
+					return fmt.Errorf("transaction is in block %v %v on eth and %s %s on hmy", eth.BlockNumber, eth.BlockHash, hmy.BlockNumber.String(), hmy.BlockHash)
+				}
+				if uint64(eth.Nonce) != hmy.Nonce.Uint64() || eth.Value.ToInt().Cmp(&hmy.Value) != 0 {
+					return fmt.Errorf("nonce %d value %s on eth and nonce %s value %s on hmy", eth.Nonce, eth.Value.ToInt(), hmy.Nonce.String(), hmy.Value.String())
+				}
+				return nil
+			},
+		}, {
+			name: "getTransactionReceipt",
+			check: func() error {
+				if ts.LastEthTransactionHash == "" {
+					return fmt.Errorf("no transaction was sent through %s", methods.METHOD_eth_sendRawTransaction)
+				}
+				var eth EthReceipt
+				if _, err := CallMethod(URL, methods.METHOD_eth_getTransactionReceipt, []interface{}{ts.LastEthTransactionHash}, &eth); err != nil {
+					return err
+				}
+				hmy, err := GetTransactionReceipt(ts.LastEthTransactionHash)
+				if err != nil {
+					return err
+				}
+				if int(eth.Status) != hmy.Status || uint64(eth.GasUsed) != hmy.GasUsed.Uint64() {
+					return fmt.Errorf("status %d gas %d on eth and status %d gas %s on hmy", eth.Status, eth.GasUsed, hmy.Status, hmy.GasUsed.String())
+				}
+				if eth.BlockHash != common.HexToHash(hmy.BlockHash) || eth.BlockNumber.ToInt().Int64() != hmy.BlockNumber {
+					return fmt.Errorf("receipt is in block %s %s on eth and %d %s on hmy", eth.BlockNumber.ToInt(), eth.BlockHash.Hex(), hmy.BlockNumber, hmy.BlockHash)
+				}
+				return nil
+			},
+		}, {
+			name: "web3Sha3",
+			check: func() error {
+				var hash hexutil.Bytes
+				if _, err := CallMethod(URL, methods.METHOD_web3_sha3, []interface{}{web3Sha3Input}, &hash); err != nil {
+					return err
+				}
+				if expected := ethcrypto.Keccak256(web3Sha3Input); !bytes.Equal(hash, expected) {
+					return fmt.Errorf("web3_sha3 returned %s, expected %s", hash, hexutil.Bytes(expected))
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start := time.Now()
+			err := tc.check()
+			metric := TestMetric{
+				Method:   METRIC_ethCrossNamespace,
+				Test:     tc.name,
+				Pass:     err == nil,
+				Duration: time.Since(start).String(),
+				Params:   []interface{}{TestAddress, pinned},
+			}
+			if err != nil {
+				metric.Error = err.Error()
+				t.Error(err)
+			}
+			testMetrics = append(testMetrics, metric)
+		})
+	}
+}
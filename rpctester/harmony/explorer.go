@@ -0,0 +1,193 @@
+package harmony
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"strconv"
+	"strings"
+)
+
+const METRIC_addressHistoryConsistency = "address_history_consistency"
+
+// maxHistoryPageSize is the page size used when fetching the whole history to compare the explorer to
+const maxHistoryPageSize = 1000
+
+// Tx views accepted by the explorer address endpoint
+const (
+	TxViewAll      = "ALL"
+	TxViewSent     = "SENT"
+	TxViewReceived = "RECEIVED"
+)
+
+// ExplorerBig is a big.Int that decodes from JSON numbers as well as decimal and hex strings,
+// the explorer API returns amounts as strings
+type ExplorerBig struct {
+	big.Int
+}
+
+// UnmarshalJSON accepts 1000, "1000" and "0x3e8"
+func (b *ExplorerBig) UnmarshalJSON(data []byte) error {
+	s := string(bytes.TrimSpace(data))
+	if s == "null" {
+		return nil
+	}
+	s = strings.Trim(s, `"`)
+	if s == "" {
+		return nil
+	}
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		if _, ok := b.SetString(s[2:], 16); !ok {
+			return fmt.Errorf("invalid hex amount %s", data)
+		}
+		return nil
+	}
+	if _, ok := b.SetString(s, 10); !ok {
+		return fmt.Errorf("invalid amount %s", data)
+	}
+	return nil
+}
+
+// MarshalJSON writes the amount as a decimal string
+func (b ExplorerBig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.String())
+}
+
+// NewAddressRequest builds the GET request for the explorer address endpoint
+// offset is the amount of transactions on every page and page is the zero based page index
+func NewAddressRequest(id string, offset, page int, txView string) (*http.Request, error) {
+	query := url.Values{}
+	query.Add("id", id)
+	query.Add("offset", strconv.Itoa(offset))
+	query.Add("page", strconv.Itoa(page))
+	query.Add("tx_view", txView)
+	return NewExplorerRequest("/address", query)
+}
+
+// NewExplorerRequest builds a GET request for path on the explorer API
+func NewExplorerRequest(path string, query url.Values) (*http.Request, error) {
+	if ExplorerURL == "" {
+		return nil, fmt.Errorf("EXPLORER_URL is not set")
+	}
+	req, err := http.NewRequest(http.MethodGet, strings.TrimRight(ExplorerURL, "/")+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+	req.URL.RawQuery = query.Encode()
+	return req, nil
+}
+
+// AddressPages walks the address endpoint page by page until a page holds less than offset transactions
+// maxPages stops the walk for addresses with a long history
+func AddressPages(id string, offset int, txView string, maxPages int) ([]*AddressResponse, error) {
+	var pages []*AddressResponse
+	for page := 0; page < maxPages; page++ {
+		response, err := Address(id, offset, page, txView)
+		if err != nil {
+			return pages, fmt.Errorf("page %d: %w", page, err)
+		}
+		pages = append(pages, response)
+		if len(response.Transactions) < offset {
+			break
+		}
+	}
+	return pages, nil
+}
+
+// CheckAddressPages verifies that no page is larger than offset, that no transaction shows up twice
+// and that every page reports the same balance
+func CheckAddressPages(pages []*AddressResponse, offset int) error {
+	seen := make(map[string]int)
+	for i, page := range pages {
+		if len(page.Transactions) > offset {
+			return fmt.Errorf("page %d holds %d transactions, offset is %d", i, len(page.Transactions), offset)
+		}
+		if page.Balance.Cmp(&pages[0].Balance.Int) != 0 {
+			return fmt.Errorf("page %d reports balance %s, page 0 reports %s", i, page.Balance.String(), pages[0].Balance.String())
+		}
+		for _, tx := range page.Transactions {
+			if previous, ok := seen[tx.ID]; ok {
+				return fmt.Errorf("transaction %s is on page %d and page %d", tx.ID, previous, i)
+			}
+			seen[tx.ID] = i
+		}
+	}
+	return nil
+}
+
+// CheckTxView verifies that every transaction in the page matches the tx view for the address
+func CheckTxView(page *AddressResponse, txView string) error {
+	if txView == TxViewAll {
+		return nil
+	}
+	addr, err := ParseStrict(page.ID)
+	if err != nil {
+		return err
+	}
+	for _, tx := range page.Transactions {
+		if tx.Type != "" && tx.Type != txView {
+			return fmt.Errorf("transaction %s has type %s in the %s view", tx.ID, tx.Type, txView)
+		}
+		party := tx.From
+		if txView == TxViewReceived {
+			party = tx.To
+		}
+		partyAddr, err := ParseStrict(party)
+		if err != nil {
+			return fmt.Errorf("transaction %s: %w", tx.ID, err)
+		}
+		if partyAddr != addr {
+			return fmt.Errorf("transaction %s is in the %s view but %s is not the %s address", tx.ID, txView, page.ID, strings.ToLower(txView))
+		}
+	}
+	return nil
+}
+
+// CheckAddressHistory compares the transactions of the walked address pages to hmyv2_getTransactionsHistory
+// The explorer and the RPC keep separate indexes and may sort differently, so the pages are compared as a whole
+// If the walk reached the last page both have to hold the same amount of transactions
+func CheckAddressHistory(pages []*AddressResponse, offset int, txView string) error {
+	if len(pages) == 0 {
+		return nil
+	}
+	var explorerTxs []Transaction
+	for _, page := range pages {
+		explorerTxs = append(explorerTxs, page.Transactions...)
+	}
+
+	// Only hashes, full transactions that fail to load are left out of the history silently
+	var history struct {
+		Transactions []string `json:"transactions"`
+	}
+	_, err := CallMethod(URL, methods.METHOD_transaction_V2_getTransactionHistory, []interface{}{
+		TransactionArguments{
+			Address:   pages[0].ID,
+			TxType:    txView,
+			FullTx:    false,
+			PageSize:  maxHistoryPageSize,
+			PageIndex: 0,
+		},
+	}, &history)
+	if err != nil {
+		return err
+	}
+	complete := len(pages[len(pages)-1].Transactions) < offset
+	if complete && len(history.Transactions) < maxHistoryPageSize && len(history.Transactions) != len(explorerTxs) {
+		return fmt.Errorf("explorer returned %d transactions, %s returned %d", len(explorerTxs), methods.METHOD_transaction_V2_getTransactionHistory, len(history.Transactions))
+	}
+	hashes := make(map[string]bool, len(history.Transactions))
+	for _, hash := range history.Transactions {
+		hashes[strings.ToLower(hash)] = true
+	}
+	for _, tx := range explorerTxs {
+		if !hashes[strings.ToLower(tx.ID)] {
+			return fmt.Errorf("transaction %s is missing from %s", tx.ID, methods.METHOD_transaction_V2_getTransactionHistory)
+		}
+	}
+	return nil
+}
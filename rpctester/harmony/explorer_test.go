@@ -0,0 +1,139 @@
+package harmony
+
+import (
+	"fmt"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"testing"
+	"time"
+)
+
+// test_address tests the explorer address endpoint, it is a GET endpoint served from EXPLORER_URL
+func test_address(t *testing.T) {
+	if ExplorerURL == "" {
+		t.Skip("EXPLORER_URL is not set")
+	}
+	type testcase struct {
+		name    string
+		id      string
+		offset  int
+		page    int
+		tx_view string
+		// wantEmpty is set for pages after the end of the history
+		wantEmpty bool
+	}
+
+	id := ToBech32(Parse(TestAddress))
+	testCases := []testcase{
+		{
+			name:    fmt.Sprintf("%s_addr", t.Name()),
+			id:      id,
+			offset:  10,
+			page:    0,
+			tx_view: TxViewAll,
+		},
+		{
+			name:    fmt.Sprintf("%s_hex_addr", t.Name()),
+			id:      TestAddress,
+			offset:  10,
+			page:    0,
+			tx_view: TxViewAll,
+		},
+		{
+			name:    fmt.Sprintf("%s_sent", t.Name()),
+			id:      id,
+			offset:  10,
+			page:    0,
+			tx_view: TxViewSent,
+		},
+		{
+			name:    fmt.Sprintf("%s_received", t.Name()),
+			id:      id,
+			offset:  10,
+			page:    0,
+			tx_view: TxViewReceived,
+		},
+		{
+			name:      fmt.Sprintf("%s_page_after_history", t.Name()),
+			id:        id,
+			offset:    10,
+			page:      100000,
+			tx_view:   TxViewAll,
+			wantEmpty: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Perform the RPC Call
+			response, err := Address(tc.id, tc.offset, tc.page, tc.tx_view)
+			if err == nil && len(response.Transactions) > tc.offset {
+				err = fmt.Errorf("page holds %d transactions, offset is %d", len(response.Transactions), tc.offset)
+			}
+			if err == nil && tc.wantEmpty && len(response.Transactions) > 0 {
+				err = fmt.Errorf("page %d is after the history but holds %d transactions", tc.page, len(response.Transactions))
+			}
+			if err == nil {
+				err = CheckTxView(response, tc.tx_view)
+			}
+			if err != nil {
+				metric := TestMetric{
+					Method: methods.METHOD_address,
+					Test:   tc.name,
+					Pass:   false,
+					Error:  err.Error(),
+				}
+				if response != nil {
+					metric.Duration = response.Duration
+				}
+				testMetrics = append(testMetrics, metric)
+				t.Error(err)
+				return
+			}
+
+			testMetrics = append(testMetrics, TestMetric{
+				Method:   methods.METHOD_address,
+				Test:     tc.name,
+				Duration: response.Duration,
+				Pass:     true,
+			})
+		})
+	}
+
+	t.Run("paging", test_addressPaging)
+}
+
+// test_addressPaging walks the address pages of every tx view with a small offset
+// and compares them to getTransactionsHistory
+func test_addressPaging(t *testing.T) {
+	const (
+		offset   = 2
+		maxPages = 25
+	)
+	id := ToBech32(Parse(TestAddress))
+	for _, txView := range []string{TxViewAll, TxViewSent, TxViewReceived} {
+		t.Run(txView, func(t *testing.T) {
+			start := time.Now()
+			pages, err := AddressPages(id, offset, txView, maxPages)
+			if err == nil {
+				err = CheckAddressPages(pages, offset)
+			}
+			for i := 0; err == nil && i < len(pages); i++ {
+				err = CheckTxView(pages[i], txView)
+			}
+			if err == nil {
+				err = CheckAddressHistory(pages, offset, txView)
+			}
+			metric := TestMetric{
+				Method:   METRIC_addressHistoryConsistency,
+				Test:     fmt.Sprintf("%s_%d_pages", t.Name(), len(pages)),
+				Pass:     err == nil,
+				Duration: time.Since(start).String(),
+			}
+			if err != nil {
+				metric.Error = err.Error()
+				t.Error(err)
+			}
+			testMetrics = append(testMetrics, metric)
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package harmony
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// filterLifecycleBlocks is the amount of new blocks the filters are polled across
+	filterLifecycleBlocks = 3
+	// filterExpiryStep is the idle time added between each filter when measuring expiry
+	filterExpiryStep = 30 * time.Second
+	// filterExpiryMax is the longest idle time tested before giving up on finding the expiry
+	filterExpiryMax = 6 * time.Minute
+)
+
+// test_waitForFilter replaces sleeping after newFilter by polling until the filter exists
+func test_waitForFilter(t *testing.T) {
+	if createdFilterID == "" {
+		t.Skip("no filter was created to wait for")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := WaitForFilter(ctx, createdFilterID); err != nil {
+		t.Errorf("filter %s was never created: %v", createdFilterID, err)
+	}
+}
+
+// test_blockFilterLifecycle polls a block filter across new blocks and checks that every block is reported exactly once
+func test_blockFilterLifecycle(t *testing.T) {
+	start := time.Now()
+	before, err := BlockNumber()
+	if err != nil {
+		failMetric(t, methods.METHOD_filter_newBlockFilter, err)
+		return
+	}
+	id, err := NewBlockFilter()
+	if err != nil {
+		failMetric(t, methods.METHOD_filter_newBlockFilter, err)
+		return
+	}
+	defer UninstallFilter(id)
+	after, err := BlockNumber()
+	if err != nil {
+		failMetric(t, methods.METHOD_filter_newBlockFilter, err)
+		return
+	}
+
+	seen := map[string]int{}
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+	for current := after; current < after+filterLifecycleBlocks; {
+		if err := WaitForBlock(ctx, current+1); err != nil {
+			failMetric(t, methods.METHOD_filter_getFilterChanges, err)
+			return
+		}
+		hashes, err := GetFilterHashes(id)
+		if err != nil {
+			failMetric(t, methods.METHOD_filter_getFilterChanges, err)
+			return
+		}
+		for _, hash := range hashes {
+			seen[common.HexToHash(hash).Hex()]++
+		}
+		if current, err = BlockNumber(); err != nil {
+			failMetric(t, methods.METHOD_filter_getFilterChanges, err)
+			return
+		}
+	}
+	// Blocks built after the block number was read can arrive on the next poll
+	last, err := BlockNumber()
+	if err != nil {
+		failMetric(t, methods.METHOD_filter_getFilterChanges, err)
+		return
+	}
+	hashes, err := GetFilterHashes(id)
+	if err != nil {
+		failMetric(t, methods.METHOD_filter_getFilterChanges, err)
+		return
+	}
+	for _, hash := range hashes {
+		seen[common.HexToHash(hash).Hex()]++
+	}
+
+	// Blocks after the filter was installed has to be reported, blocks built while it was installed may be
+	allowed := map[string]int64{}
+	for n := before + 1; n <= last; n++ {
+		block, err := GetBlockByNumber(n, BlockArgs{})
+		if err != nil {
+			failMetric(t, methods.METHOD_filter_getFilterChanges, err)
+			return
+		}
+		hash := common.HexToHash(block.Hash).Hex()
+		allowed[hash] = n
+		if n > after && seen[hash] == 0 {
+			failMetric(t, methods.METHOD_filter_getFilterChanges, fmt.Errorf("block %d %s was missed", n, hash))
+			return
+		}
+	}
+	for hash, count := range seen {
+		if count > 1 {
+			failMetric(t, methods.METHOD_filter_getFilterChanges, fmt.Errorf("block %s was reported %d times", hash, count))
+			return
+		}
+		if _, ok := allowed[hash]; !ok {
+			failMetric(t, methods.METHOD_filter_getFilterChanges, fmt.Errorf("block %s was not built while the filter was installed", hash))
+			return
+		}
+	}
+	passMetric(t, methods.METHOD_filter_getFilterChanges, start)
+}
+
+// test_pendingTransactionFilterLifecycle sends a transaction and checks that the pending transaction filter reports it once
+func test_pendingTransactionFilterLifecycle(t *testing.T) {
+	start := time.Now()
+	id, err := NewPendingTransactionFilter()
+	if err != nil {
+		failMetric(t, methods.METHOD_filter_newPendingtransactionFilter, err)
+		return
+	}
+	defer UninstallFilter(id)
+
+	addr := common.HexToAddress(TestAddress)
+	rlp, err := CreateRLPString(addr, addr, *big.NewInt(1), nil)
+	if err != nil {
+		failMetric(t, methods.METHOD_filter_getFilterChanges, err)
+		return
+	}
+	hash, err := SendRawTransaction(rlp)
+	if err != nil {
+		GetNonceManager(addr).Resync()
+		failMetric(t, methods.METHOD_filter_getFilterChanges, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+	if _, err := WaitForReceipt(ctx, hash); err != nil {
+		failMetric(t, methods.METHOD_filter_getFilterChanges, err)
+		return
+	}
+	// Poll twice, the second poll must not report the transaction again
+	seen := 0
+	for i := 0; i < 2; i++ {
+		hashes, err := GetFilterHashes(id)
+		if err != nil {
+			failMetric(t, methods.METHOD_filter_getFilterChanges, err)
+			return
+		}
+		for _, h := range hashes {
+			if common.HexToHash(h) == common.HexToHash(hash) {
+				seen++
+			}
+		}
+	}
+	if seen != 1 {
+		failMetric(t, methods.METHOD_filter_getFilterChanges, fmt.Errorf("transaction %s was reported %d times, expected once", hash, seen))
+		return
+	}
+	passMetric(t, methods.METHOD_filter_getFilterChanges, start)
+}
+
+// test_logFilterLifecycle emits a DevToken event per block and checks that every log is reported exactly once
+func test_logFilterLifecycle(t *testing.T) {
+	start := time.Now()
+	id, err := NewFilter(LogFilter{Address: SmartContractAddress.Hex()})
+	if err != nil {
+		failMetric(t, methods.METHOD_filter_newFilter, err)
+		return
+	}
+	defer UninstallFilter(id)
+
+	var expected []string
+	seen := map[string]int{}
+	for i := 0; i < filterLifecycleBlocks; i++ {
+		logs, _, _, err := emitDevtokenEvents([]devtokenCall{
+			{method: "transfer", args: []interface{}{workflowRecipient, big.NewInt(1)}},
+		})
+		if err != nil {
+			failMetric(t, methods.METHOD_filter_getFilterChanges, err)
+			return
+		}
+		for _, log := range logs {
+			expected = append(expected, logKey(log))
+		}
+		changes, err := GetFilterChanges(id)
+		if err != nil {
+			failMetric(t, methods.METHOD_filter_getFilterChanges, err)
+			return
+		}
+		for _, change := range changes {
+			ethLog, err := ToEthLog(change)
+			if err != nil {
+				failMetric(t, methods.METHOD_filter_getFilterChanges, err)
+				return
+			}
+			seen[logKey(ethLog)]++
+		}
+	}
+	for _, key := range expected {
+		if seen[key] != 1 {
+			failMetric(t, methods.METHOD_filter_getFilterChanges, fmt.Errorf("log %s was reported %d times, expected once", key, seen[key]))
+			return
+		}
+	}
+	passMetric(t, methods.METHOD_filter_getFilterChanges, start)
+}
+
+// test_uninstallFilter checks that an uninstalled filter is gone
+func test_uninstallFilter(t *testing.T) {
+	type testcase struct {
+		name    string
+		install func() (string, error)
+	}
+
+	testCases := []testcase{
+		{
+			name:    fmt.Sprintf("%s_block_filter", t.Name()),
+			install: NewBlockFilter,
+		},
+		{
+			name:    fmt.Sprintf("%s_pending_transaction_filter", t.Name()),
+			install: NewPendingTransactionFilter,
+		},
+		{
+			name: fmt.Sprintf("%s_log_filter", t.Name()),
+			install: func() (string, error) {
+				return NewFilter(LogFilter{Address: SmartContractAddress.Hex()})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start := time.Now()
+			id, err := tc.install()
+			if err != nil {
+				failMetric(t, methods.METHOD_filter_uninstallFilter, err)
+				return
+			}
+			removed, err := UninstallFilter(id)
+			if err != nil {
+				failMetric(t, methods.METHOD_filter_uninstallFilter, err)
+				return
+			}
+			if !removed {
+				failMetric(t, methods.METHOD_filter_uninstallFilter, fmt.Errorf("installed filter %s was not removed", id))
+				return
+			}
+			if removed, err = UninstallFilter(id); err != nil || removed {
+				failMetric(t, methods.METHOD_filter_uninstallFilter, fmt.Errorf("uninstalling %s twice returned %t, %v", id, removed, err))
+				return
+			}
+			if _, err := GetFilterHashes(id); err == nil {
+				failMetric(t, methods.METHOD_filter_uninstallFilter, fmt.Errorf("uninstalled filter %s can still be polled", id))
+				return
+			}
+			passMetric(t, methods.METHOD_filter_uninstallFilter, start)
+		})
+	}
+}
+
+// test_filterExpiry measures how long a filter can be idle before the node removes it
+// Polling resets the idle time, so one filter is installed per step and each is polled once
+func test_filterExpiry(t *testing.T) {
+	requireLongTest(t, "filterExpiry")
+	start := time.Now()
+	steps := int(filterExpiryMax / filterExpiryStep)
+	ids := make([]string, 0, steps)
+	for i := 0; i < steps; i++ {
+		id, err := NewBlockFilter()
+		if err != nil {
+			failMetric(t, methods.METHOD_filter_newBlockFilter, err)
+			return
+		}
+		ids = append(ids, id)
+	}
+	installed := time.Now()
+
+	alive := time.Duration(0)
+	for i, id := range ids {
+		idle := time.Duration(i+1) * filterExpiryStep
+		time.Sleep(time.Until(installed.Add(idle)))
+		if _, err := GetFilterHashes(id); err != nil {
+			t.Logf("filters expire after being idle between %s and %s", alive, idle)
+			for _, remaining := range ids[i+1:] {
+				UninstallFilter(remaining)
+			}
+			testMetrics = append(testMetrics, TestMetric{
+				Method:   methods.METHOD_filter_getFilterChanges,
+				Test:     t.Name(),
+				Pass:     true,
+				Duration: time.Since(start).String(),
+				Params:   []interface{}{fmt.Sprintf("expired between %s and %s", alive, idle)},
+			})
+			return
+		}
+		UninstallFilter(id)
+		alive = idle
+	}
+	failMetric(t, methods.METHOD_filter_getFilterChanges, fmt.Errorf("filters did not expire after being idle for %s", alive))
+}
@@ -0,0 +1,81 @@
+package harmony
+
+import (
+	"context"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+)
+
+// NewFilter installs filter using hmy_newFilter and returns its ID
+func NewFilter(filter LogFilter) (string, error) {
+	var id string
+	if _, err := CallMethod(URL, methods.METHOD_filter_newFilter, []interface{}{filter}, &id); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// NewBlockFilter installs a filter that reports the hashes of new blocks
+func NewBlockFilter() (string, error) {
+	var id string
+	if _, err := CallMethod(URL, methods.METHOD_filter_newBlockFilter, nil, &id); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// NewPendingTransactionFilter installs a filter that reports the hashes of new pending transactions
+func NewPendingTransactionFilter() (string, error) {
+	var id string
+	if _, err := CallMethod(URL, methods.METHOD_filter_newPendingtransactionFilter, nil, &id); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// UninstallFilter removes the filter, false is returned if the filter did not exist
+func UninstallFilter(id string) (bool, error) {
+	var removed bool
+	if _, err := CallMethod(URL, methods.METHOD_filter_uninstallFilter, []interface{}{id}, &removed); err != nil {
+		return false, err
+	}
+	return removed, nil
+}
+
+// GetFilterLogs returns all logs matching the installed filter using hmy_getFilterLogs
+func GetFilterLogs(id string) ([]TransactionLog, error) {
+	var logs []TransactionLog
+	if _, err := CallMethod(URL, methods.METHOD_filter_getFilterLogs, []interface{}{id}, &logs); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// GetFilterChanges returns the logs matching the installed filter since the last poll using hmy_getFilterChanges
+func GetFilterChanges(id string) ([]TransactionLog, error) {
+	var logs []TransactionLog
+	if _, err := CallMethod(URL, methods.METHOD_filter_getFilterChanges, []interface{}{id}, &logs); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// GetFilterHashes returns the block or transaction hashes reported by a block or pending transaction filter since the last poll
+func GetFilterHashes(id string) ([]string, error) {
+	var hashes []string
+	if _, err := CallMethod(URL, methods.METHOD_filter_getFilterChanges, []interface{}{id}, &hashes); err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
+// WaitForFilter polls with backoff until the node knows about the filter
+// Polling a log filter consumes its changes, use GetFilterLogs afterwards to see all logs
+func WaitForFilter(ctx context.Context, id string) error {
+	return pollWithBackoff(ctx, minBackoff, maxBackoff, func() (bool, error) {
+		resp, err := CallMethod(URL, methods.METHOD_filter_getFilterChanges, []interface{}{id}, nil)
+		if err != nil && resp == nil {
+			return false, err
+		}
+		return err == nil, nil
+	})
+}
@@ -403,10 +403,9 @@ func test_newFilter(t *testing.T) {
 				Params: []interface{}{
 					Filter{
 
-						FromBlock: "0x1",
-						ToBlock:   "0x2",
-						Address:   TestAddress,
-						Topics:    []string{"0x000000000000000000000000a94f5374fce5edbc8e2a8697c15331677e6ebf0b"},
+						Address: SmartContractAddress.Hex(),
+						// Transfer(address,address,uint256) emitted by the DevToken
+						Topics: []string{"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"},
 					},
 				},
 			},
@@ -618,51 +617,3 @@ func test_getFilterLogs(t *testing.T) {
 		})
 	}
 }
-
-func test_address(t *testing.T) {
-	type testcase struct {
-		name              string
-		id                string
-		offset            int
-		page              int
-		tx_view           string
-		expectedErrorCode int64
-	}
-
-	testCases := []testcase{
-		{
-			name:    fmt.Sprintf("%s_addr", t.Name()),
-			id:      TestAddress,
-			offset:  0,
-			page:    2,
-			tx_view: "ALL",
-		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			// Perform the RPC Call
-			response, err := Address(tc.id, tc.offset, tc.page, tc.tx_view)
-			if err != nil {
-				testMetrics = append(testMetrics, TestMetric{
-					Method: methods.METHOD_address,
-					Test:   tc.name,
-					Pass:   false,
-					Error:  err.Error(),
-				})
-				t.Error(err)
-				return
-			}
-
-			// This step validates that the returned response is the correct data type
-
-			testMetrics = append(testMetrics, TestMetric{
-				Method:   methods.METHOD_address,
-				Test:     tc.name,
-				Duration: response.Duration,
-				Pass:     true,
-			})
-
-		})
-	}
-}
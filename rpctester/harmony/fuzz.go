@@ -0,0 +1,216 @@
+package harmony
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"strings"
+	"time"
+)
+
+// FuzzFinding is a response that a well behaved RPC should never produce
+type FuzzFinding struct {
+	Method   string          `json:"method"`
+	Params   json.RawMessage `json:"params"`
+	Problem  string          `json:"problem"`
+	Status   int             `json:"status,omitempty"`
+	Code     int64           `json:"code,omitempty"`
+	Duration string          `json:"duration"`
+}
+
+func (f *FuzzFinding) Error() string {
+	return fmt.Sprintf("%s %s: %s", f.Method, f.Params, f.Problem)
+}
+
+// FuzzTimeout is how long a fuzzed request may take before it counts as a hang
+var FuzzTimeout = 10 * time.Second
+
+// standardErrorCodes are the codes defined by the JSON-RPC 2.0 specification
+var standardErrorCodes = map[int64]bool{
+	CodeParseError:     true,
+	CodeInvalidRequest: true,
+	CodeMethodNotFound: true,
+	CodeInvalidParams:  true,
+	CodeInternalError:  true,
+}
+
+// IsStandardErrorCode reports if code is defined by JSON-RPC 2.0 or inside the reserved server error range
+func IsStandardErrorCode(code int64) bool {
+	return standardErrorCodes[code] || (code <= -32000 && code >= -32099)
+}
+
+// FuzzParams wraps raw fuzz input into a params value
+// Input that is not valid JSON is sent as a single string param so the request still reaches the method
+func FuzzParams(input []byte) json.RawMessage {
+	if json.Valid(input) {
+		return json.RawMessage(input)
+	}
+	data, _ := json.Marshal([]string{string(input)})
+	return data
+}
+
+// ProbeRPC sends the method with params to url and returns a finding if the node misbehaves
+// A nil finding means the node answered with a result or a standard JSON-RPC error
+func ProbeRPC(url string, method string, params json.RawMessage) *FuzzFinding {
+	finding := &FuzzFinding{Method: method, Params: params}
+	payload, err := json.Marshal(struct {
+		ID      string          `json:"id"`
+		JsonRPC string          `json:"jsonrpc"`
+		Method  string          `json:"method"`
+		Params  json.RawMessage `json:"params"`
+	}{"1", "2.0", method, params})
+	if err != nil {
+		finding.Problem = err.Error()
+		return finding
+	}
+
+	// A fresh client so a broken connection is not reused by the next probe
+	client := &http.Client{Timeout: FuzzTimeout, Transport: &http.Transport{DisableKeepAlives: true}}
+	start := time.Now()
+	resp, err := client.Post(url, "application/json", bytes.NewBuffer(payload))
+	finding.Duration = time.Since(start).String()
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			finding.Problem = fmt.Sprintf("hang: no response within %s", FuzzTimeout)
+		} else {
+			finding.Problem = fmt.Sprintf("connection failed: %v", err)
+		}
+		return finding
+	}
+	defer resp.Body.Close()
+	finding.Status = resp.StatusCode
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		finding.Problem = fmt.Sprintf("connection failed while reading body: %v", err)
+		return finding
+	}
+	if resp.StatusCode >= http.StatusInternalServerError {
+		finding.Problem = fmt.Sprintf("server error: %s", resp.Status)
+		return finding
+	}
+
+	var br BaseResponse
+	if err := json.Unmarshal(body, &br); err != nil {
+		finding.Problem = fmt.Sprintf("response is not JSON-RPC: %v", err)
+		return finding
+	}
+	if br.Error == nil {
+		if br.Result == nil {
+			finding.Problem = "response has neither result nor error"
+			return finding
+		}
+		return nil
+	}
+	if !IsStandardErrorCode(br.Error.Code) {
+		finding.Code = br.Error.Code
+		finding.Problem = fmt.Sprintf("non-standard error code %d: %s", br.Error.Code, br.Error.Message)
+		return finding
+	}
+	return nil
+}
+
+// fuzzValues are malformed single values grouped by what they try to break
+var fuzzValues = map[string][]string{
+	"wrongType": {
+		`{}`, `true`, `null`, `"string"`, `[[]]`, `0.5`, `""`,
+	},
+	"hugeNumber": {
+		`18446744073709551616`,
+		`1e400`,
+		`-1e400`,
+		`"0x` + strings.Repeat("f", 128) + `"`,
+		`"0x"`,
+		`"0xg"`,
+	},
+	"negativeBlock": {
+		`-1`, `"-0x1"`, `"-1"`, `-9223372036854775808`, `"latest-1"`,
+	},
+	"invalidBech32": {
+		`"one1"`,
+		`"one1invalid"`,
+		`"one1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq"`,
+		`"ONE1pdv9lrdwl0rg5vglh4xtyrv3wjk3wsqket7zxy"`,
+		`"one1pdv9lrdwl0rg5vglh4xtyrv3wjk3wsqket7zxz"`,
+		`"0x` + strings.Repeat("0", 39) + `"`,
+	},
+}
+
+// fuzzCategories are the names of the generators in FuzzGenerate
+var fuzzCategories = []string{"wrongType", "hugeNumber", "negativeBlock", "invalidBech32", "oversizedArray", "deepNesting"}
+
+// FuzzGenerate produces a malformed params array from rnd
+// The array mixes the values above with oversized arrays and deeply nested JSON
+func FuzzGenerate(rnd *rand.Rand) json.RawMessage {
+	count := rnd.Intn(4) + 1
+	values := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		values = append(values, fuzzValue(rnd, fuzzCategories[rnd.Intn(len(fuzzCategories))]))
+	}
+	return json.RawMessage("[" + strings.Join(values, ",") + "]")
+}
+
+// fuzzCategoriesByParam are the malformed values most likely to slip through the parsing of a param type
+var fuzzCategoriesByParam = map[methods.ParamType][]string{
+	methods.ParamAddress:     {"invalidBech32", "wrongType", "hugeNumber"},
+	methods.ParamBlockNumber: {"negativeBlock", "hugeNumber", "wrongType"},
+	methods.ParamIndex:       {"negativeBlock", "hugeNumber", "wrongType"},
+	methods.ParamEpoch:       {"negativeBlock", "hugeNumber", "wrongType"},
+	methods.ParamInt:         {"negativeBlock", "hugeNumber", "wrongType"},
+	methods.ParamObject:      {"deepNesting", "oversizedArray", "wrongType"},
+}
+
+// FuzzGenerateFor produces malformed params shaped after the registered params of method
+// Every param gets a value that targets its type, unregistered methods fall back to FuzzGenerate
+func FuzzGenerateFor(rnd *rand.Rand, method string) json.RawMessage {
+	m, ok := methods.Lookup(method)
+	if !ok || len(m.Params) == 0 {
+		return FuzzGenerate(rnd)
+	}
+	values := make([]string, 0, len(m.Params))
+	for _, param := range m.Params {
+		categories, ok := fuzzCategoriesByParam[param.Type]
+		if !ok {
+			categories = fuzzCategories
+		}
+		values = append(values, fuzzValue(rnd, categories[rnd.Intn(len(categories))]))
+	}
+	return json.RawMessage("[" + strings.Join(values, ",") + "]")
+}
+
+func fuzzValue(rnd *rand.Rand, category string) string {
+	switch category {
+	case "oversizedArray":
+		size := 1000 << rnd.Intn(8)
+		return "[" + strings.TrimSuffix(strings.Repeat("0,", size), ",") + "]"
+	case "deepNesting":
+		depth := 100 << rnd.Intn(8)
+		return strings.Repeat("[", depth) + strings.Repeat("]", depth)
+	default:
+		values := fuzzValues[category]
+		return values[rnd.Intn(len(values))]
+	}
+}
+
+// FuzzSeeds returns one params array per malformed value and generator, used to seed the corpus
+func FuzzSeeds() []json.RawMessage {
+	seeds := []json.RawMessage{}
+	for _, category := range fuzzCategories {
+		values, ok := fuzzValues[category]
+		if !ok {
+			rnd := rand.New(rand.NewSource(0))
+			values = []string{fuzzValue(rnd, category)}
+		}
+		for _, value := range values {
+			seeds = append(seeds, json.RawMessage("["+value+"]"))
+		}
+	}
+	return seeds
+}
@@ -0,0 +1,36 @@
+package harmony
+
+import (
+	"math/rand"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"testing"
+)
+
+// FuzzRPCParams sends malformed params to every method in methods.All
+// The seed corpus runs with go test, go test -fuzz=FuzzRPCParams explores further
+// Failing inputs are minimized and saved in testdata/fuzz/FuzzRPCParams so they reproduce with go test -run
+func FuzzRPCParams(f *testing.F) {
+	if testing.Short() {
+		f.Skip("fuzzing the RPC takes too long in short mode")
+	}
+	seeds := FuzzSeeds()
+	// Give every method at least one seed and every seed at least one method
+	count := len(methods.All)
+	if len(seeds) > count {
+		count = len(seeds)
+	}
+	for i := 0; i < count; i++ {
+		f.Add(uint16(i%len(methods.All)), []byte(seeds[i%len(seeds)]))
+	}
+	rnd := rand.New(rand.NewSource(1))
+	for i, method := range methods.All {
+		f.Add(uint16(i), []byte(FuzzGenerateFor(rnd, method)))
+	}
+
+	f.Fuzz(func(t *testing.T, index uint16, input []byte) {
+		method := methods.All[int(index)%len(methods.All)]
+		if finding := ProbeRPC(URL, method, FuzzParams(input)); finding != nil {
+			t.Fatalf("%s returned %s after %s", method, finding.Problem, finding.Duration)
+		}
+	})
+}
@@ -0,0 +1,295 @@
+package harmony
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	METRIC_gasEstimateAccuracy = "gas_estimate_accuracy"
+	METRIC_gasPriceAccepted    = "gas_price_accepted"
+
+	// transferGas is the gas used by a plain transfer
+	transferGas = 21000
+)
+
+// GasCase is a transaction that the gas estimate is measured for
+type GasCase struct {
+	Name  string
+	To    common.Address
+	Value *big.Int
+	Data  []byte
+}
+
+// GasEstimate compares the estimate of hmy_estimateGas with the gas used by the transaction
+type GasEstimate struct {
+	Name      string  `json:"name"`
+	Estimated uint64  `json:"estimated"`
+	Used      uint64  `json:"used"`
+	Ratio     float64 `json:"ratio"`
+	Hash      string  `json:"hash,omitempty"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// UnderEstimated is true if a transaction sent with the estimate as gas limit would have run out of gas
+func (ge GasEstimate) UnderEstimated() bool {
+	return ge.Error == "" && ge.Estimated < ge.Used
+}
+
+// GasEstimateSummary sums up the ratios of the estimates that could be measured
+type GasEstimateSummary struct {
+	Measured       int     `json:"measured"`
+	UnderEstimated int     `json:"underEstimated"`
+	MinRatio       float64 `json:"minRatio"`
+	MaxRatio       float64 `json:"maxRatio"`
+	MeanRatio      float64 `json:"meanRatio"`
+}
+
+// GasPriceReport is the result of probing the lowest gas price the node accepts
+type GasPriceReport struct {
+	Suggested         *big.Int `json:"suggested"`
+	SuggestedAccepted bool     `json:"suggestedAccepted"`
+	// MinimumAccepted is the lowest price accepted, MaximumRejected the highest price rejected
+	MinimumAccepted *big.Int `json:"minimumAccepted"`
+	MaximumRejected *big.Int `json:"maximumRejected,omitempty"`
+	// Margin is how many times the minimum the suggested price is
+	Margin float64 `json:"margin"`
+	Probes int     `json:"probes"`
+	// Mined is true if every accepted probe was included, including the one at the lowest price
+	Mined bool `json:"mined"`
+}
+
+// GasCases returns plain transfers and Devtoken calls from the test account
+// Every call uses a new recipient, so storage is written for the first time as well as updated
+func GasCases() ([]GasCase, error) {
+	parsed, err := DevtokenABI()
+	if err != nil {
+		return nil, err
+	}
+	key, err := ethcrypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	from := common.HexToAddress(TestAddress)
+	recipient := ethcrypto.PubkeyToAddress(key.PublicKey)
+
+	cases := []GasCase{
+		{Name: "transfer_self", To: from, Value: big.NewInt(1)},
+		{Name: "transfer_new_account", To: recipient, Value: big.NewInt(1)},
+	}
+	calls := []struct {
+		name   string
+		method string
+		args   []interface{}
+	}{
+		{name: "devtoken_transfer_new_holder", method: "transfer", args: []interface{}{recipient, big.NewInt(1)}},
+		{name: "devtoken_transfer_existing_holder", method: "transfer", args: []interface{}{recipient, big.NewInt(1)}},
+		{name: "devtoken_approve", method: "approve", args: []interface{}{recipient, big.NewInt(1000)}},
+		{name: "devtoken_increase_allowance", method: "increaseAllowance", args: []interface{}{recipient, big.NewInt(1)}},
+		{name: "devtoken_mint", method: "mint", args: []interface{}{from, big.NewInt(1000)}},
+		{name: "devtoken_burn", method: "burn", args: []interface{}{from, big.NewInt(1)}},
+	}
+	for _, call := range calls {
+		data, err := parsed.Pack(call.method, call.args...)
+		if err != nil {
+			return nil, err
+		}
+		cases = append(cases, GasCase{Name: call.name, To: SmartContractAddress, Value: big.NewInt(0), Data: data})
+	}
+	return cases, nil
+}
+
+// EstimateGas returns the estimate of hmy_estimateGas for a call from the test account
+func EstimateGas(to common.Address, value *big.Int, data []byte) (uint64, error) {
+	var estimate hexutil.Uint64
+	_, err := CallMethod(URL, methods.METHOD_contract_estimateGas, []interface{}{RpcCallArgs{
+		From:  common.HexToAddress(TestAddress).Hex(),
+		To:    to.Hex(),
+		Value: (*hexutil.Big)(value),
+		Data:  hexutil.Encode(data),
+	}}, &estimate)
+	return uint64(estimate), err
+}
+
+// MeasureGasEstimate estimates the gas of the case, then sends it with the configured GAS_LIMIT and compares the estimate to the gas used
+func MeasureGasEstimate(gasCase GasCase) GasEstimate {
+	result := GasEstimate{Name: gasCase.Name}
+	fail := func(err error) GasEstimate {
+		result.Error = err.Error()
+		return result
+	}
+	estimate, err := EstimateGas(gasCase.To, gasCase.Value, gasCase.Data)
+	if err != nil {
+		return fail(err)
+	}
+	result.Estimated = estimate
+
+	from := common.HexToAddress(TestAddress)
+	// The configured limit instead of the estimate, so that an underestimate still shows the gas used
+	rlp, err := CreateRLPStringWithGasLimit(gasCase.To, from, *gasCase.Value, gasCase.Data, auth.GasLimit)
+	if err != nil {
+		return fail(err)
+	}
+	hash, err := SendRawTransaction(rlp)
+	if err != nil {
+		GetNonceManager(from).Resync()
+		return fail(err)
+	}
+	result.Hash = hash
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+	receipt, err := WaitForReceipt(ctx, hash)
+	if err != nil {
+		return fail(err)
+	}
+	if receipt.Status != 1 {
+		return fail(fmt.Errorf("%s failed with status %d", hash, receipt.Status))
+	}
+	result.Used = receipt.GasUsed.Uint64()
+	if result.Used > 0 {
+		result.Ratio = float64(result.Estimated) / float64(result.Used)
+	}
+	return result
+}
+
+// SummarizeGasEstimates computes the ratios over the estimates without errors
+func SummarizeGasEstimates(estimates []GasEstimate) GasEstimateSummary {
+	var summary GasEstimateSummary
+	total := 0.0
+	for _, estimate := range estimates {
+		if estimate.Error != "" || estimate.Used == 0 {
+			continue
+		}
+		if summary.Measured == 0 || estimate.Ratio < summary.MinRatio {
+			summary.MinRatio = estimate.Ratio
+		}
+		if estimate.Ratio > summary.MaxRatio {
+			summary.MaxRatio = estimate.Ratio
+		}
+		if estimate.UnderEstimated() {
+			summary.UnderEstimated++
+		}
+		summary.Measured++
+		total += estimate.Ratio
+	}
+	if summary.Measured > 0 {
+		summary.MeanRatio = total / float64(summary.Measured)
+	}
+	return summary
+}
+
+// GasPrice returns the price suggested by hmy_gasPrice
+func GasPrice() (*big.Int, error) {
+	var price hexutil.Big
+	if _, err := CallMethod(URL, methods.METHOD_protocol_V1_gasPrice, nil, &price); err != nil {
+		return nil, err
+	}
+	return price.ToInt(), nil
+}
+
+// isUnderpriced is true if the node rejected a transaction because of its gas price
+func isUnderpriced(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "underpriced") || strings.Contains(msg, "gas price") || strings.Contains(msg, "price too low")
+}
+
+// ProbeGasPrice sends self transfers with different gas prices to find the lowest price the node accepts
+// The suggested price is tried first, the search then narrows down to 1% of the price or until maxProbes transactions are sent
+// Accepted probes are real transactions and use a nonce each
+func ProbeGasPrice(maxProbes int) (*GasPriceReport, error) {
+	suggested, err := GasPrice()
+	if err != nil {
+		return nil, err
+	}
+	report := &GasPriceReport{Suggested: suggested}
+	from := common.HexToAddress(TestAddress)
+	var lastAccepted string
+	probe := func(price *big.Int) (bool, error) {
+		report.Probes++
+		rlp, err := CreateRLPStringWithGasPrice(from, from, *big.NewInt(0), nil, transferGas, price)
+		if err != nil {
+			return false, err
+		}
+		hash, err := SendRawTransaction(rlp)
+		if err != nil {
+			GetNonceManager(from).Resync()
+			if isUnderpriced(err) {
+				return false, nil
+			}
+			return false, fmt.Errorf("probing gas price %s: %w", price, err)
+		}
+		lastAccepted = hash
+		return true, nil
+	}
+
+	// Find an accepted price, doubling from the suggested one
+	high := new(big.Int).Set(suggested)
+	if high.Sign() == 0 {
+		high.SetInt64(1)
+	}
+	var low *big.Int
+	for {
+		accepted, err := probe(high)
+		if err != nil {
+			return report, err
+		}
+		if high.Cmp(suggested) == 0 {
+			report.SuggestedAccepted = accepted
+		}
+		if accepted {
+			break
+		}
+		if report.Probes >= maxProbes {
+			return report, fmt.Errorf("no gas price up to %s was accepted", high)
+		}
+		low = new(big.Int).Set(high)
+		high.Mul(high, big.NewInt(2))
+	}
+	// Find a rejected price, the lowest possible is 1 wei
+	if low == nil {
+		low = big.NewInt(0)
+		if accepted, err := probe(big.NewInt(1)); err != nil {
+			return report, err
+		} else if accepted {
+			high.SetInt64(1)
+		}
+	}
+	// Narrow down between the highest rejected and the lowest accepted price
+	for report.Probes < maxProbes && high.Cmp(big.NewInt(1)) > 0 {
+		gap := new(big.Int).Sub(high, low)
+		if gap.Cmp(new(big.Int).Div(high, big.NewInt(100))) <= 0 || gap.Cmp(big.NewInt(1)) <= 0 {
+			break
+		}
+		mid := new(big.Int).Add(low, new(big.Int).Div(gap, big.NewInt(2)))
+		accepted, err := probe(mid)
+		if err != nil {
+			return report, err
+		}
+		if accepted {
+			high = mid
+		} else {
+			low = mid
+		}
+	}
+	report.MinimumAccepted = high
+	if low.Sign() > 0 {
+		report.MaximumRejected = low
+	}
+	report.Margin, _ = new(big.Float).Quo(new(big.Float).SetInt(suggested), new(big.Float).SetInt(high)).Float64()
+
+	// The transactions are sent in nonce order, so the last accepted one being mined means every probe was
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+	if _, err := WaitForReceipt(ctx, lastAccepted); err == nil {
+		report.Mined = true
+	}
+	return report, nil
+}
@@ -0,0 +1,66 @@
+package harmony
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// test_estimateGasAccuracy sends plain transfers and Devtoken calls and compares hmy_estimateGas with the gas used
+// An underestimate fails, a wallet using it as gas limit would run out of gas
+func test_estimateGasAccuracy(t *testing.T) {
+	cases, err := GasCases()
+	if err != nil {
+		failMetric(t, METRIC_gasEstimateAccuracy, err)
+		return
+	}
+	for _, gasCase := range cases {
+		t.Run(gasCase.Name, func(t *testing.T) {
+			start := time.Now()
+			estimate := MeasureGasEstimate(gasCase)
+			if estimate.Error != "" {
+				failMetric(t, METRIC_gasEstimateAccuracy, fmt.Errorf("%s", estimate.Error))
+				return
+			}
+			t.Logf("estimated %d, used %d, ratio %.3f", estimate.Estimated, estimate.Used, estimate.Ratio)
+			if estimate.UnderEstimated() {
+				failMetric(t, METRIC_gasEstimateAccuracy, fmt.Errorf("estimated %d gas but %d was used", estimate.Estimated, estimate.Used))
+				return
+			}
+			passMetric(t, METRIC_gasEstimateAccuracy, start)
+		})
+	}
+}
+
+// test_gasPriceAccepted sends a transfer at exactly the price from hmy_gasPrice, it has to be accepted and mined
+func test_gasPriceAccepted(t *testing.T) {
+	start := time.Now()
+	price, err := GasPrice()
+	if err != nil {
+		failMetric(t, METRIC_gasPriceAccepted, err)
+		return
+	}
+	from := common.HexToAddress(TestAddress)
+	rlp, err := CreateRLPStringWithGasPrice(from, from, *big.NewInt(0), nil, transferGas, price)
+	if err != nil {
+		failMetric(t, METRIC_gasPriceAccepted, err)
+		return
+	}
+	hash, err := SendRawTransaction(rlp)
+	if err != nil {
+		GetNonceManager(from).Resync()
+		failMetric(t, METRIC_gasPriceAccepted, fmt.Errorf("transaction at the suggested gas price %s was rejected: %w", price, err))
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+	if _, err := WaitForReceipt(ctx, hash); err != nil {
+		failMetric(t, METRIC_gasPriceAccepted, fmt.Errorf("transaction at the suggested gas price %s was not mined: %w", price, err))
+		return
+	}
+	passMetric(t, METRIC_gasPriceAccepted, start)
+}
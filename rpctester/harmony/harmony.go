@@ -38,8 +38,10 @@ var (
 	auth          *bind.TransactOpts
 	deployedToken *devtoken.Devtoken
 
-	TestAddress                 string
-	URL                         string
+	TestAddress string
+	URL         string
+	// ExplorerURL is the base URL of the explorer API set by EXPLORER_URL, empty if there is no explorer to test
+	ExplorerURL                 string
 	smartContractDeploymentHash string
 	SmartContractAddress        common.Address
 	// Bigint representation of 1
@@ -61,30 +63,19 @@ func init() {
 	ONE = big.NewInt(1000000000000000000)
 
 	// Load .dotenv file
-	environment := os.Getenv("RPCTESTER_ENVIRONMENT")
-
-	if environment == "production" {
-		if err := godotenv.Load(".env-prod"); err != nil {
-			log.Fatal("Error loading .env-prod file")
-		}
-	} else if environment == "dev" {
-		if err := godotenv.Load(".env-dev"); err != nil {
-			log.Fatal("Error loading .env-dev file")
-		}
-	} else if environment == "test" {
-		if err := godotenv.Load(".env-test"); err != nil {
-			log.Fatal("Error loading .env-test file")
-		}
-	} else {
-		if err := godotenv.Load(".env"); err != nil {
-			log.Fatal("Error loading .env file")
-		}
+	profile := ProfileFile()
+	if err := godotenv.Load(profile); err != nil {
+		log.Fatalf("Error loading %s file", profile)
 	}
 
 	TestAddress = os.Getenv("ADDRESS")
 	URL = os.Getenv("NET_URL")
+	ExplorerURL = os.Getenv("EXPLORER_URL")
 	smartContractAddr := os.Getenv("SMART_CONTRACT_ADDRESS")
 	smartContractDeploymentHash = os.Getenv("SMART_CONTRACT_DEPLOY_HASH")
+	if confirmations, err := strconv.ParseInt(os.Getenv("CONFIRMATIONS"), 10, 64); err == nil {
+		Confirmations = confirmations
+	}
 	// Create eth client
 	ethClient, auth = crypto.NewClient()
 	// Load the Smart Contract
@@ -102,6 +93,8 @@ type TestResults struct {
 	AddressUsed string       `json:"addressUsed"`
 	Network     string       `json:"network"`
 	Metrics     []TestMetric `json:"metrics"`
+	// Uncovered are the registered methods that no test recorded a metric for
+	Uncovered []string `json:"uncovered"`
 }
 
 type TestMetric struct {
@@ -124,7 +117,8 @@ type BaseRequest struct {
 
 // BaseResponse is the base RPC response, but added extra metrics
 type BaseResponse struct {
-	ID      string          `json:"id"`
+	// ID is kept raw since it echoes the request id, which may be a string, number or null
+	ID      json.RawMessage `json:"id"`
 	JsonRPC string          `json:"jsonrpc"`
 	Result  json.RawMessage `json:"result"`
 	// Error is only present when errors occurs
@@ -135,10 +129,12 @@ type BaseResponse struct {
 	Duration string `json:"duration"`
 }
 
+// AddressResponse is the response of the explorer address endpoint
 type AddressResponse struct {
-	ID           string        `json:"id"`
-	Balance      big.Int       `json:"balance"`
-	Transactions []Transaction `json:"txs" `
+	ID                  string        `json:"id"`
+	Balance             ExplorerBig   `json:"balance"`
+	Transactions        []Transaction `json:"txs"`
+	StakingTransactions []Transaction `json:"staking_txs"`
 	// Custom data fields not part of rpc response
 	Method string `json:"method"`
 	// Not part of the default message
@@ -148,6 +144,8 @@ type AddressResponse struct {
 type RPCError struct {
 	Code    int64
 	Message string
+	// Data holds additional information about the error, such as revert data
+	Data json.RawMessage `json:"data,omitempty"`
 }
 
 func GenerateReport() {
@@ -155,6 +153,7 @@ func GenerateReport() {
 		AddressUsed: TestAddress,
 		Network:     URL,
 		Metrics:     testMetrics,
+		Uncovered:   UncoveredMethods(testMetrics),
 	}
 	// After all tests, Generate report
 	data, err := json.Marshal(results)
@@ -169,10 +168,16 @@ func GenerateReport() {
 
 // Call will trigger a request with a payload to the RPC method given and marshal response into interface
 func Call(payload []byte, method string) (*BaseResponse, error) {
+	return CallURL(URL, payload, method)
+}
+
+// CallURL is the same as Call but allows the RPC endpoint to be selected, this is needed
+// when talking to more than one shard
+func CallURL(url string, payload []byte, method string) (*BaseResponse, error) {
 	// Store request time in Response
 
 	start := time.Now()
-	resp, err := httpClient.Post(fmt.Sprintf("%s", URL), "application/json", bytes.NewBuffer(payload))
+	resp, err := httpClient.Post(url, "application/json", bytes.NewBuffer(payload))
 	if err != nil {
 		return nil, err
 	}
@@ -201,21 +206,50 @@ func Call(payload []byte, method string) (*BaseResponse, error) {
 	return &br, nil
 }
 
-// Addres fetches address, this does not work as the other rpc calls
-func Address(id string, offset, page int, tx_view string) (*AddressResponse, error) {
-	client := &http.Client{}
-
-	req, _ := http.NewRequest("GET", fmt.Sprintf("%s/address", URL), nil)
-	req.Header.Add("Content-Type", "application/json")
+// CallMethod builds a request for the method and params, sends it to url and unmarshals the result into result
+// An error is returned if the RPC responds with an error
+func CallMethod(url string, method string, params []interface{}, result interface{}) (*BaseResponse, error) {
+	if params == nil {
+		params = []interface{}{}
+	}
+	payload, err := json.Marshal(BaseRequest{
+		ID:      "1",
+		JsonRPC: "2.0",
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := CallURL(url, payload, method)
+	if err != nil {
+		return nil, err
+	}
+	if resp.JsonRPC != "2.0" {
+		return resp, fmt.Errorf("%s: %w: %q", method, ErrInvalidVersion, resp.JsonRPC)
+	}
+	if !bytes.Equal(bytes.TrimSpace(resp.ID), []byte(`"1"`)) {
+		return resp, fmt.Errorf("%s: %w: %s", method, ErrIDMismatch, resp.ID)
+	}
+	if resp.Error != nil {
+		return resp, fmt.Errorf("%s: %d %s", method, resp.Error.Code, resp.Error.Message)
+	}
+	if result != nil && resp.Result != nil {
+		if err := json.Unmarshal(resp.Result, result); err != nil {
+			return resp, err
+		}
+	}
+	return resp, nil
+}
 
-	q := req.URL.Query()
-	q.Add("id", id)
-	q.Add("offset", strconv.Itoa(offset))
-	q.Add("page", strconv.Itoa(page))
-	q.Add("tx_view", tx_view)
-	req.URL.RawQuery = q.Encode()
+// Address fetches a page of the explorer address endpoint, this is a GET endpoint and does not work as the other rpc calls
+func Address(id string, offset, page int, tx_view string) (*AddressResponse, error) {
+	req, err := NewAddressRequest(id, offset, page, tx_view)
+	if err != nil {
+		return nil, err
+	}
 	start := time.Now()
-	resp, err := client.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -243,61 +277,155 @@ func Address(id string, offset, page int, tx_view string) (*AddressResponse, err
 }
 
 // CreateRLPString is a wrapper to help with generating RLP
+// The transaction is sent to the same shard that it originates from
 func CreateRLPString(to common.Address, from common.Address, amount big.Int, data []byte) (string, error) {
-	nonce, err := ethClient.PendingNonceAt(context.Background(), from)
+	shardID, err := crypto.GetShardID()
 	if err != nil {
 		return "", err
 	}
+	return CreateCrossShardRLPString(to, from, amount, data, uint32(shardID))
+}
+
+// CreateCrossShardRLPString is used to generate RLP for a transaction that is received on toShardID
+func CreateCrossShardRLPString(to common.Address, from common.Address, amount big.Int, data []byte, toShardID uint32) (string, error) {
+	signer := crypto.GetSigner()
+	if signer.Address() != from {
+		return "", fmt.Errorf("configured signer %s cannot sign for %s", signer.Address().Hex(), from.Hex())
+	}
+	return CreateSignedRLPString(signer, to, amount, data, toShardID)
+}
 
+// CreateSignedRLPString generates RLP for a transaction sent from and signed by signer
+func CreateSignedRLPString(signer crypto.Signer, to common.Address, amount big.Int, data []byte, toShardID uint32) (string, error) {
+	return createSignedRLPString(signer, &to, amount, data, toShardID, 0, nil)
+}
+
+// CreateRLPStringWithGasLimit generates RLP for a transaction that uses gasLimit instead of estimating it
+// This is needed for transactions that are expected to fail, since estimating their gas fails
+func CreateRLPStringWithGasLimit(to common.Address, from common.Address, amount big.Int, data []byte, gasLimit uint64) (string, error) {
+	signer := crypto.GetSigner()
+	if signer.Address() != from {
+		return "", fmt.Errorf("configured signer %s cannot sign for %s", signer.Address().Hex(), from.Hex())
+	}
 	shardID, err := crypto.GetShardID()
 	if err != nil {
 		return "", err
 	}
-	gasPrice, err := ethClient.SuggestGasPrice(context.Background())
+	return createSignedRLPString(signer, &to, amount, data, uint32(shardID), gasLimit, nil)
+}
+
+// CreateContractRLPString generates RLP for a transaction that deploys code
+func CreateContractRLPString(from common.Address, code []byte) (string, error) {
+	signer := crypto.GetSigner()
+	if signer.Address() != from {
+		return "", fmt.Errorf("configured signer %s cannot sign for %s", signer.Address().Hex(), from.Hex())
+	}
+	shardID, err := crypto.GetShardID()
 	if err != nil {
 		return "", err
 	}
-	var selectedGasLimit uint64
-	if data != nil {
-		gasLimit, err := ethClient.EstimateGas(context.Background(), ethereum.CallMsg{
-			To:   &to,
+	return createSignedRLPString(signer, nil, *big.NewInt(0), code, uint32(shardID), 0, nil)
+}
+
+// CreateRLPStringWithGasPrice generates RLP for a transaction with a fixed gas limit and gas price
+// This is used to probe the lowest gas price the node accepts
+func CreateRLPStringWithGasPrice(to common.Address, from common.Address, amount big.Int, data []byte, gasLimit uint64, gasPrice *big.Int) (string, error) {
+	signer := crypto.GetSigner()
+	if signer.Address() != from {
+		return "", fmt.Errorf("configured signer %s cannot sign for %s", signer.Address().Hex(), from.Hex())
+	}
+	shardID, err := crypto.GetShardID()
+	if err != nil {
+		return "", err
+	}
+	return createSignedRLPString(signer, &to, amount, data, uint32(shardID), gasLimit, gasPrice)
+}
+
+// CreateSignedTransfer is CreateSignedRLPString that also returns the nonce used
+// The nonce should be released with the NonceManager of the signer if the transaction is rejected
+func CreateSignedTransfer(signer crypto.Signer, to common.Address, amount big.Int, data []byte, toShardID uint32) (string, uint64, error) {
+	return createSignedTransaction(signer, &to, amount, data, toShardID, 0, nil)
+}
+
+// createSignedRLPString signs a transaction to to, or a contract creation if to is nil
+// The gas limit is estimated if gasLimit is 0 and the gas price is suggested by the node if gasPrice is nil
+func createSignedRLPString(signer crypto.Signer, to *common.Address, amount big.Int, data []byte, toShardID uint32, gasLimit uint64, gasPrice *big.Int) (string, error) {
+	rlp, _, err := createSignedTransaction(signer, to, amount, data, toShardID, gasLimit, gasPrice)
+	return rlp, err
+}
+
+// createSignedTransaction is createSignedRLPString that also returns the nonce handed out for the transaction
+func createSignedTransaction(signer crypto.Signer, to *common.Address, amount big.Int, data []byte, toShardID uint32, gasLimit uint64, gasPrice *big.Int) (string, uint64, error) {
+	shardID, err := crypto.GetShardID()
+	if err != nil {
+		return "", 0, err
+	}
+	if gasPrice == nil {
+		gasPrice, err = ethClient.SuggestGasPrice(context.Background())
+		if err != nil {
+			return "", 0, err
+		}
+	}
+	selectedGasLimit := gasLimit
+	if data != nil && selectedGasLimit == 0 {
+		estimated, err := ethClient.EstimateGas(context.Background(), ethereum.CallMsg{
+			From: signer.Address(),
+			To:   to,
 			Data: data,
 		})
 		if err != nil {
-			return "", err
+			return "", 0, err
 		}
-		selectedGasLimit = gasLimit
+		selectedGasLimit = estimated
 	}
 	if selectedGasLimit == 0 {
 		selectedGasLimit = auth.GasLimit
 	}
+	chainID, err := ethClient.ChainID(context.Background())
+	if err != nil {
+		return "", 0, err
+	}
+	// Nonces are handed out locally so that concurrent senders does not reuse them
+	nonces := GetNonceManager(signer.Address())
+	nonce, err := nonces.Next()
+	if err != nil {
+		return "", 0, err
+	}
 	// Create TX with Harmony Flavor
-	hmy_tx := types.NewTransaction(nonce, to, uint32(shardID), &amount, selectedGasLimit, gasPrice, data)
+	var hmy_tx *types.Transaction
+	if to == nil {
+		hmy_tx = types.NewContractCreation(nonce, uint32(shardID), &amount, selectedGasLimit, gasPrice, data)
+	} else {
+		hmy_tx = types.NewCrossShardTransaction(nonce, to, uint32(shardID), toShardID, &amount, selectedGasLimit, gasPrice, data)
+	}
 
-	chainID, err := ethClient.ChainID(context.Background())
+	rawTxHex, _, err := signTransaction(signer, hmy_tx, chainID)
 	if err != nil {
-		return "", err
+		nonces.Release(nonce)
+		return "", 0, err
 	}
+	return rawTxHex, nonce, nil
+}
 
-	signedTx, err := types.SignTx(hmy_tx, types.NewEIP155Signer(chainID), crypto.GetPrivateKey())
+// signTransaction signs tx for chainID and returns the RLP and the hash of the signed transaction
+func signTransaction(signer crypto.Signer, tx *types.Transaction, chainID *big.Int) (string, common.Hash, error) {
+	txSigner := types.NewEIP155Signer(chainID)
+	sig, err := signer.SignHash(txSigner.Hash(tx).Bytes())
 	if err != nil {
-		return "", err
+		return "", common.Hash{}, err
+	}
+	signedTx, err := tx.WithSignature(txSigner, sig)
+	if err != nil {
+		return "", common.Hash{}, err
 	}
 	ts := types.Transactions{signedTx}
-
-	rawTxHex := hexutil.Encode(ts.GetRlp(0))
-	return rawTxHex, nil
+	return hexutil.Encode(ts.GetRlp(0)), signedTx.Hash(), nil
 }
 
 // CreateStakingRLPString is a wrapper to help with generating RLP for staking requests
 // delgator and validator is sent as ONE accounts format ie bech32
 // addr
 func CreateStakingRLPString(delegator string, validator string, amount *big.Int, data []byte) (string, error) {
-	nonce, err := ethClient.PendingNonceAt(context.Background(), common.HexToAddress(TestAddress))
-	if err != nil {
-		return "", err
-	}
-
 	gasPrice, err := ethClient.SuggestGasPrice(context.Background())
 	if err != nil {
 		return "", err
@@ -320,7 +448,17 @@ func CreateStakingRLPString(delegator string, validator string, amount *big.Int,
 		return "", err
 	}
 
+	// Staking transactions share nonces with the normal transactions of the account
+	nonces := GetNonceManager(common.HexToAddress(TestAddress))
+	nonce, err := nonces.Next()
+	if err != nil {
+		return "", err
+	}
 	stakingTx, err := staking.NewStakingTransaction(nonce, selectedGasLimit, gasPrice, delegateStakePayloadMaker)
+	if err != nil {
+		nonces.Release(nonce)
+		return "", err
+	}
 
 	// Currently Bugged on Testnet & Devnet REturns ChainiD 16777....?
 	// Trnasaciton needs 4 or 2
@@ -329,13 +467,21 @@ func CreateStakingRLPString(delegator string, validator string, amount *big.Int,
 	// 	return "", err
 	// }
 	// HardCode to 2 for now
-	signedTx, err := staking.Sign(stakingTx, staking.NewEIP155Signer(big.NewInt(2)), crypto.GetPrivateKey())
+	stakingSigner := staking.NewEIP155Signer(big.NewInt(2))
+	sig, err := crypto.GetSigner().SignHash(stakingSigner.Hash(stakingTx).Bytes())
+	if err != nil {
+		nonces.Release(nonce)
+		return "", err
+	}
+	signedTx, err := stakingTx.WithSignature(stakingSigner, sig)
 	if err != nil {
+		nonces.Release(nonce)
 		return "", err
 	}
 
 	enc, err := rlp.EncodeToBytes(signedTx)
 	if err != nil {
+		nonces.Release(nonce)
 		return "", err
 	}
 	hexSignature := hexutil.Encode(enc)
@@ -3,6 +3,8 @@ package harmony
 import (
 	"encoding/json"
 	"errors"
+	"os"
+	"strings"
 	"testing"
 	"time"
 )
@@ -25,6 +27,9 @@ func Test_RPC_Sanity(t *testing.T) {
 	t.Run("FilterMethods", test_FilterMethods)
 	t.Run("TransactionMethods", test_TransactionMethods)
 	t.Run("TraceMethods", test_TraceMethods)
+	t.Run("ArchiveState", ts.test_archiveState)
+	t.Run("EthMethods", test_EthMethods)
+	t.Run("Conformance", test_Conformance)
 	// Now generate report
 	GenerateReport()
 
@@ -36,6 +41,7 @@ func test_TransactionMethods(t *testing.T) {
 	t.Run("electedValidators_V1", ts.test_V2_getAllElectedValidators)
 	// Begin by sending Transaction so we can get transaction hashes to use
 	t.Run("sendRawTransaction", ts.test_sendRawTransaction)
+	t.Run("concurrentTransactions", ts.test_concurrentTransactions)
 	// https://github.com/harmony-one/bounties/issues/117#issuecomment-1170274370
 	t.Run("sendRawStakingTransaction", ts.test_sendRawStakingTransaction)
 
@@ -45,12 +51,13 @@ func test_TransactionMethods(t *testing.T) {
 	t.Run("getPendingCrossLinks_V2", test_V2_getPendingCrossLinks)
 	t.Run("getPendingCXReceipts_V1", test_V1_getPendingCXReceipts)
 	t.Run("getPendingCXReceipts_V2", test_V2_getPendingCXReceipts)
-	t.Run("getCXReceiptByHash_V1", test_V1_getCXReceiptByHash)
-	t.Run("getCXReceiptByHash_V2", test_V2_getCXReceiptByHash)
+	t.Run("crossShardTransaction", ts.test_crossShardTransaction)
+	t.Run("getCXReceiptByHash_V1", ts.test_V1_getCXReceiptByHash)
+	t.Run("getCXReceiptByHash_V2", ts.test_V2_getCXReceiptByHash)
 	t.Run("getPendingTransaction_V1", test_V1_pendingTransactions)
 	t.Run("getPendingTransaction_V2", test_V2_pendingTransactions)
-	t.Log("Giving the network some time to congest transactions sent")
-	time.Sleep(10 * time.Second)
+	t.Run("txPoolAdmission", test_txPoolAdmission)
+	t.Run("waitForReceipt", ts.test_waitForReceipt)
 	t.Run("getStakingTransactionByBlockHashAndIndex", ts.test_V1_getStakingTransactionByBlockHashAndIndex)
 	t.Run("getStakingTransactionByBlockHasAndIndex_V2", ts.test_V2_getStakingTransactionByBlockHashAndIndex)
 	t.Run("getStakingTransactionByBlockNumberAndIndex", ts.test_V1_getStakingTransactionByBlockNumberAndIndex)
@@ -59,6 +66,8 @@ func test_TransactionMethods(t *testing.T) {
 	t.Run("getStakingTransactionByHash_V2", ts.test_V2_getStakingTransactionByHash)
 	t.Run("getTransactionHistory_V1", ts.test_V1_getTransactionHistory)
 	t.Run("getTransactionHistory_V2", ts.test_V2_getTransactionHistory)
+	t.Run("transactionHistoryPaging", ts.test_transactionHistoryPaging)
+	t.Run("stakingTransactionHistoryPaging", ts.test_stakingTransactionHistoryPaging)
 	t.Run("getTransactionReceipt_V1", ts.test_V1_getTransactionReceipt)
 	t.Run("getTransactionReceipt_V2", ts.test_V2_getTransactionReceipt)
 	t.Run("getBlockTransactionCountByHash_V1", ts.test_V1_getBlockTransactionCountByHash)
@@ -90,21 +99,24 @@ func test_AccountMethods(t *testing.T) {
 	t.Run("getTransactionCount_V1", test_V1_hmy_getTransactionCount)
 	t.Run("getBalance_V2", test_V2_hmy_getBalance)
 	t.Run("getBalance_V1", test_V1_hmy_getBalance)
-	// Address is an explorer function, skip for now
-	//t.Run("address", test_address)
+	t.Run("address", test_address)
 }
 
 // test_FilterMethods is used to call Filter RPC methods and validate their return data
 func test_FilterMethods(t *testing.T) {
 	t.Run("newFilter", test_newFilter)
-	// Add some delay between newFilter and getFilterLogs so it has the time to actually create
-	t.Log("Waiting for Filter to be created")
-	time.Sleep(1 * time.Second)
+	t.Run("waitForFilter", test_waitForFilter)
 	t.Run("getFilterLogs", test_getFilterLogs)
 	t.Run("newPendingTransactionFilter", test_NewPendingTransactionFilter)
 	t.Run("newBlockFilter", test_NewBlockFilter)
 	t.Run("getFilterChanges", test_getFilterChanges)
 	t.Run("getLogs", test_getLogs)
+	t.Run("eventLogs", ts.test_eventLogs)
+	t.Run("blockFilterLifecycle", test_blockFilterLifecycle)
+	t.Run("pendingTransactionFilterLifecycle", test_pendingTransactionFilterLifecycle)
+	t.Run("logFilterLifecycle", test_logFilterLifecycle)
+	t.Run("uninstallFilter", test_uninstallFilter)
+	t.Run("filterExpiry", test_filterExpiry)
 }
 
 func test_ContractMethods(t *testing.T) {
@@ -112,18 +124,23 @@ func test_ContractMethods(t *testing.T) {
 	t.Run("getCode", test_getCode)
 	t.Run("call", test_call)
 	t.Run("estimateGas", test_EstimateGas)
+	t.Run("estimateGasAccuracy", test_estimateGasAccuracy)
+	t.Run("gasPriceAccepted", test_gasPriceAccepted)
+	t.Run("devtokenWorkflow", ts.test_devtokenWorkflow)
 }
 
 func test_TraceMethods(t *testing.T) {
 	t.Run("traceBlock", ts.test_traceBlock)
 	t.Run("traceTransaction", ts.test_traceTransaction)
+	t.Run("traceCallTrees", ts.test_traceCallTrees)
 }
 
 func test_ProtocolMethods(t *testing.T) {
-	// t.Run("getSuperCommitee_v2", ts.test_getSuperCommitees)
+	t.Run("getSuperCommittees", test_getSuperCommittees)
 	t.Run("isLastBlocK", test_isLastBlock)
 	t.Run("epochLastBlock", test_epochLastBlock)
 	t.Run("lastestHeader", ts.test_latestHeader)
+	t.Run("getHeaderByNumber_V2", ts.test_getHeaderByNumber)
 	t.Run("shardingStructure", test_getShardingStructure)
 	t.Run("blockNumber_V1", test_V1_blockNumber)
 	t.Run("blockNumber_V2", test_V2_blockNumber)
@@ -134,6 +151,7 @@ func test_ProtocolMethods(t *testing.T) {
 	t.Run("getEpoch_V1", test_V1_getEpoch)
 	t.Run("getEpoch_V2", test_V2_getEpoch)
 	t.Run("getLeader", test_getLeader)
+	t.Run("epochTransition", test_epochTransition)
 }
 
 func test_StakingMethods(t *testing.T) {
@@ -165,6 +183,7 @@ func test_StakingMethods(t *testing.T) {
 	t.Run("isBlockSigner_V2", ts.test_V2_isBlockSigner)
 	t.Run("getBlockSigners_V1", ts.test_V1_getBlockSigners)
 	t.Run("getBlockSigners_V2", ts.test_V2_getBlockSigners)
+	t.Run("stakingConsistency", test_stakingConsistency)
 
 }
 
@@ -225,3 +244,35 @@ func callAndValidateDataType(t *testing.T, testName string, expectedErrorCode in
 	}
 	return *resp, nil
 }
+
+// failMetric reports err as a failed metric of method for the running test
+func failMetric(t *testing.T, method string, err error) {
+	testMetrics = append(testMetrics, TestMetric{
+		Method: method,
+		Test:   t.Name(),
+		Pass:   false,
+		Error:  err.Error(),
+	})
+	t.Error(err)
+}
+
+// passMetric reports a passed metric of method for the running test, which started at start
+func passMetric(t *testing.T, method string, start time.Time) {
+	testMetrics = append(testMetrics, TestMetric{
+		Method:   method,
+		Test:     t.Name(),
+		Pass:     true,
+		Duration: time.Since(start).String(),
+	})
+}
+
+// requireLongTest skips tests that wait for minutes unless name is listed in LONG_TESTS
+// LONG_TESTS is a comma separated list of test names, or all to run every long test
+func requireLongTest(t *testing.T, name string) {
+	for _, enabled := range strings.Split(os.Getenv("LONG_TESTS"), ",") {
+		if enabled = strings.TrimSpace(enabled); enabled == name || enabled == "all" {
+			return
+		}
+	}
+	t.Skipf("%s takes minutes, add it to LONG_TESTS to run it", name)
+}
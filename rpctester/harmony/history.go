@@ -0,0 +1,170 @@
+package harmony
+
+import (
+	"encoding/json"
+	"fmt"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Orders accepted by the history methods, ASC is used when no order is given
+const (
+	OrderAsc  = "ASC"
+	OrderDesc = "DESC"
+)
+
+// maxHistoryPages stops walking the history of addresses with a long history
+const maxHistoryPages = 1000
+
+// HistoryEntry is a transaction from getTransactionsHistory or getStakingTransactionsHistory
+// Timestamp is only known when the history is fetched with FullTx
+type HistoryEntry struct {
+	Hash      string
+	Timestamp int64
+}
+
+// historyTransaction holds the fields used from full transactions of every API version
+// Timestamps are numbers in V2 and hex strings in V1
+type historyTransaction struct {
+	Hash      string          `json:"hash"`
+	Timestamp json.RawMessage `json:"timestamp"`
+}
+
+// GetHistoryPage fetches one page of the transaction or staking transaction history
+func GetHistoryPage(method string, args TransactionArguments) ([]HistoryEntry, error) {
+	var result map[string]json.RawMessage
+	if _, err := CallMethod(URL, method, []interface{}{args}, &result); err != nil {
+		return nil, err
+	}
+	key := "transactions"
+	if method == methods.METHOD_transaction_V1_getStakingTransactionHistory || method == methods.METHOD_transaction_V2_getStakingTransactionHistory {
+		key = "staking_transactions"
+	}
+	raw, ok := result[key]
+	if !ok {
+		return nil, fmt.Errorf("%s: result has no %s", method, key)
+	}
+
+	if !args.FullTx {
+		var hashes []string
+		if err := json.Unmarshal(raw, &hashes); err != nil {
+			return nil, err
+		}
+		entries := make([]HistoryEntry, 0, len(hashes))
+		for _, hash := range hashes {
+			entries = append(entries, HistoryEntry{Hash: strings.ToLower(hash)})
+		}
+		return entries, nil
+	}
+	var txs []historyTransaction
+	if err := json.Unmarshal(raw, &txs); err != nil {
+		return nil, err
+	}
+	entries := make([]HistoryEntry, 0, len(txs))
+	for _, tx := range txs {
+		timestamp, err := parseTimestamp(tx.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("%s: transaction %s: %w", method, tx.Hash, err)
+		}
+		entries = append(entries, HistoryEntry{Hash: strings.ToLower(tx.Hash), Timestamp: timestamp})
+	}
+	return entries, nil
+}
+
+// parseTimestamp accepts a JSON number or a hex string
+func parseTimestamp(raw json.RawMessage) (int64, error) {
+	var hex string
+	if err := json.Unmarshal(raw, &hex); err == nil {
+		n, err := hexutil.DecodeUint64(hex)
+		return int64(n), err
+	}
+	return strconv.ParseInt(string(raw), 10, 64)
+}
+
+// WalkHistory fetches page after page, starting at args.PageIndex, until a page is not full
+func WalkHistory(method string, args TransactionArguments) ([][]HistoryEntry, error) {
+	if args.PageSize <= 0 {
+		return nil, fmt.Errorf("page size has to be positive to walk the history, got %d", args.PageSize)
+	}
+	var pages [][]HistoryEntry
+	for i := 0; i < maxHistoryPages; i++ {
+		page, err := GetHistoryPage(method, args)
+		if err != nil {
+			return pages, fmt.Errorf("page %d: %w", args.PageIndex, err)
+		}
+		pages = append(pages, page)
+		if len(page) < args.PageSize {
+			return pages, nil
+		}
+		args.PageIndex++
+	}
+	return pages, fmt.Errorf("history has more than %d pages of %d", maxHistoryPages, args.PageSize)
+}
+
+// CheckHistoryPages verifies that every page but the last is full, that no page is larger than pageSize,
+// that no transaction is on two pages and, if the timestamps are known, that the transactions are sorted by order
+// The transactions of all pages are returned in the order they were listed
+func CheckHistoryPages(pages [][]HistoryEntry, pageSize int, order string, fullTx bool) ([]HistoryEntry, error) {
+	var all []HistoryEntry
+	seen := make(map[string]int)
+	for i, page := range pages {
+		if len(page) > pageSize {
+			return nil, fmt.Errorf("page %d holds %d transactions, page size is %d", i, len(page), pageSize)
+		}
+		if i < len(pages)-1 && len(page) != pageSize {
+			return nil, fmt.Errorf("page %d holds %d transactions but is not the last page, page size is %d", i, len(page), pageSize)
+		}
+		for _, entry := range page {
+			if previous, ok := seen[entry.Hash]; ok {
+				return nil, fmt.Errorf("transaction %s is on page %d and page %d", entry.Hash, previous, i)
+			}
+			seen[entry.Hash] = i
+			all = append(all, entry)
+		}
+	}
+	if fullTx {
+		for i := 1; i < len(all); i++ {
+			prev, cur := all[i-1], all[i]
+			if order == OrderDesc && cur.Timestamp > prev.Timestamp {
+				return nil, fmt.Errorf("%s order: %s at %d is listed after %s at %d", order, cur.Hash, cur.Timestamp, prev.Hash, prev.Timestamp)
+			}
+			if order != OrderDesc && cur.Timestamp < prev.Timestamp {
+				return nil, fmt.Errorf("%s order: %s at %d is listed after %s at %d", order, cur.Hash, cur.Timestamp, prev.Hash, prev.Timestamp)
+			}
+		}
+	}
+	return all, nil
+}
+
+// CheckHistoryComplete verifies that entries holds exactly the expected hashes
+func CheckHistoryComplete(entries []HistoryEntry, expected []string) error {
+	want := make(map[string]bool, len(expected))
+	for _, hash := range expected {
+		want[strings.ToLower(hash)] = true
+	}
+	got := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		if !want[entry.Hash] {
+			return fmt.Errorf("unexpected transaction %s in the history", entry.Hash)
+		}
+		got[entry.Hash] = true
+	}
+	for hash := range want {
+		if !got[hash] {
+			return fmt.Errorf("transaction %s is missing from the history, got %d of %d transactions", hash, len(got), len(want))
+		}
+	}
+	return nil
+}
+
+// HistoryHashes returns the hashes of entries
+func HistoryHashes(entries []HistoryEntry) []string {
+	hashes := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		hashes = append(hashes, entry.Hash)
+	}
+	return hashes
+}
@@ -0,0 +1,226 @@
+package harmony
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"percybolmer/rpc-shard-testing/rpctester/crypto"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// historyReceived and historySent are the transactions generated for the fresh history account
+	historyReceived = 3
+	historySent     = 2
+	// historyIndexTimeout is how long the node may take to add included transactions to the history
+	historyIndexTimeout = 30 * time.Second
+)
+
+// historyPageSizes are walked for every method, tx type and order, 1 and the full history size
+// are the edges, the others split the history into full pages and a partial last page
+var historyPageSizes = []int{1, 2, 3, historyReceived + historySent, 100}
+
+// test_transactionHistoryPaging sends a known set of transactions to and from a fresh account
+// and walks its history with every combination of page size, order, tx type and fullTx
+func (ts *testSuite) test_transactionHistoryPaging(t *testing.T) {
+	expected, fresh, err := generateHistory()
+	if err != nil {
+		failMetric(t, methods.METHOD_transaction_V2_getTransactionHistory, err)
+		return
+	}
+	id := ToBech32(fresh)
+
+	// Transactions are indexed when their block is committed, which may be after the receipt is available
+	ctx, cancel := context.WithTimeout(context.Background(), historyIndexTimeout)
+	defer cancel()
+	err = pollWithBackoff(ctx, minBackoff, maxBackoff, func() (bool, error) {
+		page, err := GetHistoryPage(methods.METHOD_transaction_V2_getTransactionHistory, TransactionArguments{Address: id, TxType: TxViewAll, PageSize: 100})
+		return len(page) >= len(expected[TxViewAll]), err
+	})
+	if err != nil {
+		failMetric(t, methods.METHOD_transaction_V2_getTransactionHistory, fmt.Errorf("the history of %s does not hold the %d generated transactions, the node may not keep the explorer index: %w", id, len(expected[TxViewAll]), err))
+		return
+	}
+
+	for _, method := range []string{methods.METHOD_transaction_V1_getTransactionHistory, methods.METHOD_transaction_V2_getTransactionHistory} {
+		for _, txType := range []string{TxViewAll, TxViewSent, TxViewReceived} {
+			for _, order := range []string{OrderAsc, OrderDesc} {
+				for _, fullTx := range []bool{false, true} {
+					for _, pageSize := range historyPageSizes {
+						args := TransactionArguments{Address: id, TxType: txType, FullTx: fullTx, PageSize: pageSize, Order: order}
+						name := fmt.Sprintf("%s_%s_%s_full_%t_size_%d", method, txType, order, fullTx, pageSize)
+						t.Run(name, func(t *testing.T) {
+							start := time.Now()
+							pages, err := WalkHistory(method, args)
+							if err != nil {
+								failMetric(t, method, err)
+								return
+							}
+							all, err := CheckHistoryPages(pages, pageSize, order, fullTx)
+							if err == nil {
+								err = CheckHistoryComplete(all, expected[txType])
+							}
+							if err != nil {
+								failMetric(t, method, err)
+								return
+							}
+							passMetric(t, method, start)
+						})
+					}
+				}
+			}
+		}
+	}
+
+	t.Run("page_after_history", func(t *testing.T) {
+		start := time.Now()
+		page, err := GetHistoryPage(methods.METHOD_transaction_V2_getTransactionHistory, TransactionArguments{Address: id, TxType: TxViewAll, PageSize: 2, PageIndex: 100})
+		if err == nil && len(page) != 0 {
+			err = fmt.Errorf("page after the history holds %d transactions", len(page))
+		}
+		if err != nil {
+			failMetric(t, methods.METHOD_transaction_V2_getTransactionHistory, err)
+			return
+		}
+		passMetric(t, methods.METHOD_transaction_V2_getTransactionHistory, start)
+	})
+}
+
+// test_stakingTransactionHistoryPaging walks the staking history of the test account, which delegates in sendRawStakingTransaction
+// The history may hold older staking transactions, so completeness is checked against a single page holding all of them
+func (ts *testSuite) test_stakingTransactionHistoryPaging(t *testing.T) {
+	id := ToBech32(Parse(TestAddress))
+	for _, method := range []string{methods.METHOD_transaction_V1_getStakingTransactionHistory, methods.METHOD_transaction_V2_getStakingTransactionHistory} {
+		expected := make(map[string][]string)
+		for _, txType := range []string{TxViewAll, TxViewSent, TxViewReceived} {
+			page, err := GetHistoryPage(method, TransactionArguments{Address: id, TxType: txType, PageSize: maxHistoryPageSize})
+			if err != nil {
+				failMetric(t, method, err)
+				return
+			}
+			expected[txType] = HistoryHashes(page)
+		}
+		if err := checkHistoryTypes(expected); err != nil {
+			failMetric(t, method, err)
+			return
+		}
+		if ts.LastStakingTransactionHash != "" {
+			found := false
+			for _, hash := range expected[TxViewSent] {
+				found = found || common.HexToHash(hash) == common.HexToHash(ts.LastStakingTransactionHash)
+			}
+			if !found {
+				failMetric(t, method, fmt.Errorf("staking transaction %s is missing from the SENT history of %s", ts.LastStakingTransactionHash, id))
+				return
+			}
+		}
+
+		for _, txType := range []string{TxViewAll, TxViewSent, TxViewReceived} {
+			for _, order := range []string{OrderAsc, OrderDesc} {
+				for _, fullTx := range []bool{false, true} {
+					for _, pageSize := range []int{1, 2, 3, 100} {
+						args := TransactionArguments{Address: id, TxType: txType, FullTx: fullTx, PageSize: pageSize, Order: order}
+						name := fmt.Sprintf("%s_%s_%s_full_%t_size_%d", method, txType, order, fullTx, pageSize)
+						t.Run(name, func(t *testing.T) {
+							start := time.Now()
+							pages, err := WalkHistory(method, args)
+							if err != nil {
+								failMetric(t, method, err)
+								return
+							}
+							all, err := CheckHistoryPages(pages, pageSize, order, fullTx)
+							if err == nil {
+								err = CheckHistoryComplete(all, expected[txType])
+							}
+							if err != nil {
+								failMetric(t, method, err)
+								return
+							}
+							passMetric(t, method, start)
+						})
+					}
+				}
+			}
+		}
+	}
+}
+
+// generateHistory funds a fresh account with historyReceived transactions and sends historySent transactions back
+// Every transaction waits for the previous to be included, so they land in different blocks and sort the same way every time
+func generateHistory() (map[string][]string, common.Address, error) {
+	key, err := ethcrypto.GenerateKey()
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	fresh := crypto.NewKeySigner(key)
+	funder := common.HexToAddress(TestAddress)
+	shardID, err := crypto.GetShardID()
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	expected := map[string][]string{}
+	send := func(rlp string, err error, nonces *NonceManager, txType string) error {
+		if err != nil {
+			return err
+		}
+		hash, err := SendRawTransaction(rlp)
+		if err != nil {
+			nonces.Resync()
+			return err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+		defer cancel()
+		receipt, err := WaitForReceipt(ctx, hash)
+		if err != nil {
+			return err
+		}
+		if receipt.Status != 1 {
+			return fmt.Errorf("%s failed with status %d", hash, receipt.Status)
+		}
+		expected[txType] = append(expected[txType], hash)
+		expected[TxViewAll] = append(expected[TxViewAll], hash)
+		return nil
+	}
+
+	// Receive first so the fresh account can pay for its own transactions
+	for i := 0; i < historyReceived; i++ {
+		rlp, err := CreateRLPString(fresh.Address(), funder, *new(big.Int).Div(ONE, big.NewInt(10)), nil)
+		if err := send(rlp, err, GetNonceManager(funder), TxViewReceived); err != nil {
+			return nil, common.Address{}, fmt.Errorf("funding %s: %w", fresh.Address().Hex(), err)
+		}
+	}
+	for i := 0; i < historySent; i++ {
+		rlp, err := createSignedRLPString(fresh, &funder, *big.NewInt(1), nil, uint32(shardID), 21000, nil)
+		if err := send(rlp, err, GetNonceManager(fresh.Address()), TxViewSent); err != nil {
+			return nil, common.Address{}, fmt.Errorf("sending from %s: %w", fresh.Address().Hex(), err)
+		}
+	}
+	return expected, fresh.Address(), nil
+}
+
+// checkHistoryTypes verifies that the SENT and RECEIVED histories are part of the ALL history and together make it up
+func checkHistoryTypes(expected map[string][]string) error {
+	all := make(map[string]bool)
+	for _, hash := range expected[TxViewAll] {
+		all[hash] = true
+	}
+	covered := make(map[string]bool)
+	for _, txType := range []string{TxViewSent, TxViewReceived} {
+		for _, hash := range expected[txType] {
+			if !all[hash] {
+				return fmt.Errorf("transaction %s is in the %s history but not in the %s history", hash, txType, TxViewAll)
+			}
+			covered[hash] = true
+		}
+	}
+	if len(covered) != len(all) {
+		return fmt.Errorf("the %s and %s histories hold %d of the %d transactions in the %s history", TxViewSent, TxViewReceived, len(covered), len(all), TxViewAll)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package harmony
+
+import (
+	"encoding/json"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// LogTopics are the topic positions of a LogFilter
+// An empty position matches any topic, a position with several topics matches any of them
+type LogTopics [][]common.Hash
+
+// MarshalJSON encodes wildcards as null and single topics as a string
+func (lt LogTopics) MarshalJSON() ([]byte, error) {
+	positions := make([]interface{}, 0, len(lt))
+	for _, position := range lt {
+		switch len(position) {
+		case 0:
+			positions = append(positions, nil)
+		case 1:
+			positions = append(positions, position[0])
+		default:
+			positions = append(positions, position)
+		}
+	}
+	return json.Marshal(positions)
+}
+
+// GetLogs queries hmy_getLogs with filter
+func GetLogs(filter LogFilter) ([]TransactionLog, error) {
+	var logs []TransactionLog
+	if _, err := CallMethod(URL, methods.METHOD_filter_getLogs, []interface{}{filter}, &logs); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
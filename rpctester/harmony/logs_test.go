@@ -0,0 +1,287 @@
+package harmony
+
+import (
+	"context"
+	"fmt"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// test_eventLogs emits known DevToken events and checks that hmy_getLogs, hmy_getFilterLogs
+// and hmy_getFilterChanges return exactly the logs computed from the receipts
+// The network is expected to have no other DevToken activity while the test runs
+func (ts *testSuite) test_eventLogs(t *testing.T) {
+	parsed, err := DevtokenABI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	transferTopic := parsed.Events["Transfer"].ID
+	approvalTopic := parsed.Events["Approval"].ID
+	owner := common.HexToAddress(TestAddress)
+	ownerTopic := common.BytesToHash(owner.Bytes())
+	otherRecipient := common.HexToAddress("0x000000000000000000000000000000000000bEEF")
+	otherTopic := common.BytesToHash(otherRecipient.Bytes())
+	contract := SmartContractAddress.Hex()
+
+	type testcase struct {
+		name   string
+		filter LogFilter
+		// firstBlockOnly limits the block range to the block of the first emitted event
+		firstBlockOnly bool
+		// changeFilterID is the filter installed before the events were emitted
+		changeFilterID string
+	}
+
+	testCases := []*testcase{
+		{
+			name:   fmt.Sprintf("%s_address", t.Name()),
+			filter: LogFilter{Address: contract},
+		},
+		{
+			name:   fmt.Sprintf("%s_address_topic", t.Name()),
+			filter: LogFilter{Address: contract, Topics: LogTopics{{transferTopic}}},
+		},
+		{
+			name:   fmt.Sprintf("%s_or_topics", t.Name()),
+			filter: LogFilter{Address: contract, Topics: LogTopics{{transferTopic, approvalTopic}}},
+		},
+		{
+			name:   fmt.Sprintf("%s_wildcard_topic", t.Name()),
+			filter: LogFilter{Address: contract, Topics: LogTopics{{}, {ownerTopic}}},
+		},
+		{
+			name:   fmt.Sprintf("%s_indexed_recipient", t.Name()),
+			filter: LogFilter{Address: contract, Topics: LogTopics{{transferTopic}, {}, {otherTopic}}},
+		},
+		{
+			name:   fmt.Sprintf("%s_topic_any_address", t.Name()),
+			filter: LogFilter{Topics: LogTopics{{approvalTopic}}},
+		},
+		{
+			name:           fmt.Sprintf("%s_single_block", t.Name()),
+			filter:         LogFilter{Address: contract},
+			firstBlockOnly: true,
+		},
+		{
+			name:   fmt.Sprintf("%s_other_address", t.Name()),
+			filter: LogFilter{Address: common.HexToAddress("0x0000000000000000000000000000000000000001").Hex()},
+		},
+	}
+
+	// Filters for getFilterChanges has to exist before the events are emitted
+	for _, tc := range testCases {
+		id, err := NewFilter(tc.filter)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		defer UninstallFilter(id)
+		tc.changeFilterID = id
+	}
+	for _, tc := range testCases {
+		if _, err := GetFilterChanges(tc.changeFilterID); err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+	}
+
+	emitted, fromBlock, toBlock, err := emitDevtokenEvents([]devtokenCall{
+		{method: "transfer", args: []interface{}{workflowRecipient, ONE}},
+		{method: "transfer", args: []interface{}{otherRecipient, ONE}},
+		{method: "approve", args: []interface{}{workflowRecipient, ONE}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range testCases {
+		rangeFilter := tc.filter
+		rangeFilter.FromBlock = hexutil.EncodeUint64(fromBlock)
+		rangeFilter.ToBlock = hexutil.EncodeUint64(toBlock)
+		if tc.firstBlockOnly {
+			rangeFilter.ToBlock = rangeFilter.FromBlock
+		}
+		expected := filterLogs(emitted, rangeFilter)
+
+		t.Run(fmt.Sprintf("%s_getLogs", tc.name), func(t *testing.T) {
+			start := time.Now()
+			logs, err := GetLogs(rangeFilter)
+			checkLogSet(t, methods.METHOD_filter_getLogs, start, expected, logs, err)
+		})
+		t.Run(fmt.Sprintf("%s_getFilterLogs", tc.name), func(t *testing.T) {
+			start := time.Now()
+			id, err := NewFilter(rangeFilter)
+			var logs []TransactionLog
+			if err == nil {
+				defer UninstallFilter(id)
+				logs, err = GetFilterLogs(id)
+			}
+			checkLogSet(t, methods.METHOD_filter_getFilterLogs, start, expected, logs, err)
+		})
+		t.Run(fmt.Sprintf("%s_getFilterChanges", tc.name), func(t *testing.T) {
+			start := time.Now()
+			logs, err := GetFilterChanges(tc.changeFilterID)
+			checkLogSet(t, methods.METHOD_filter_getFilterChanges, start, filterLogs(emitted, tc.filter), logs, err)
+		})
+	}
+}
+
+// devtokenCall is a state changing call to the Devtoken
+type devtokenCall struct {
+	method string
+	args   []interface{}
+}
+
+// emitDevtokenEvents sends the calls, waits for them and returns the logs from the receipts
+// together with the first and last block that holds one of the transactions
+func emitDevtokenEvents(calls []devtokenCall) ([]ethtypes.Log, uint64, uint64, error) {
+	hashes := make([]string, 0, len(calls))
+	for _, call := range calls {
+		hash, err := SendDevtokenTransaction(call.method, call.args...)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		hashes = append(hashes, hash)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+	var (
+		logs               []ethtypes.Log
+		fromBlock, toBlock uint64
+	)
+	for i, hash := range hashes {
+		receipt, err := WaitForReceipt(ctx, hash)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		if receipt.Status != 1 {
+			return nil, 0, 0, fmt.Errorf("%s failed with status %d", hash, receipt.Status)
+		}
+		block := uint64(receipt.BlockNumber)
+		if i == 0 || block < fromBlock {
+			fromBlock = block
+		}
+		if block > toBlock {
+			toBlock = block
+		}
+		for _, log := range receipt.Logs {
+			ethLog, err := ToEthLog(log)
+			if err != nil {
+				return nil, 0, 0, err
+			}
+			logs = append(logs, ethLog)
+		}
+	}
+	return logs, fromBlock, toBlock, nil
+}
+
+// filterLogs applies the filter rules of the RPC to logs
+func filterLogs(logs []ethtypes.Log, filter LogFilter) []ethtypes.Log {
+	var matched []ethtypes.Log
+	for _, log := range logs {
+		if filter.Address != "" && log.Address != common.HexToAddress(filter.Address) {
+			continue
+		}
+		if filter.FromBlock != "" {
+			if from, err := hexutil.DecodeUint64(filter.FromBlock); err == nil && log.BlockNumber < from {
+				continue
+			}
+		}
+		if filter.ToBlock != "" {
+			if to, err := hexutil.DecodeUint64(filter.ToBlock); err == nil && log.BlockNumber > to {
+				continue
+			}
+		}
+		if !matchTopics(log.Topics, filter.Topics) {
+			continue
+		}
+		matched = append(matched, log)
+	}
+	return matched
+}
+
+// matchTopics returns true if every position of the filter matches the topic at the same position
+func matchTopics(topics []common.Hash, filter LogTopics) bool {
+	if len(filter) > len(topics) {
+		return false
+	}
+	for i, position := range filter {
+		if len(position) == 0 {
+			continue
+		}
+		found := false
+		for _, topic := range position {
+			if topic == topics[i] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// checkLogSet asserts that the logs returned by the RPC are exactly the expected set and reports the metric
+func checkLogSet(t *testing.T, method string, start time.Time, expected []ethtypes.Log, got []TransactionLog, err error) {
+	if err == nil {
+		err = compareLogSets(expected, got)
+	}
+	if err != nil {
+		failMetric(t, method, err)
+		return
+	}
+	passMetric(t, method, start)
+}
+
+// compareLogSets checks that got holds the expected logs and nothing else
+func compareLogSets(expected []ethtypes.Log, got []TransactionLog) error {
+	want := make([]string, 0, len(expected))
+	for _, log := range expected {
+		want = append(want, logKey(log))
+	}
+	have := make([]string, 0, len(got))
+	for _, log := range got {
+		ethLog, err := ToEthLog(log)
+		if err != nil {
+			return err
+		}
+		have = append(have, logKey(ethLog))
+	}
+	sort.Strings(want)
+	sort.Strings(have)
+
+	missing, unexpected := diffSorted(want, have)
+	if len(missing) > 0 || len(unexpected) > 0 {
+		return fmt.Errorf("got %d logs, expected %d: missing %v, unexpected %v", len(have), len(want), missing, unexpected)
+	}
+	return nil
+}
+
+// diffSorted returns the entries only found in a and the entries only found in b
+func diffSorted(a, b []string) ([]string, []string) {
+	var onlyA, onlyB []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			i++
+			j++
+		case a[i] < b[j]:
+			onlyA = append(onlyA, a[i])
+			i++
+		default:
+			onlyB = append(onlyB, b[j])
+			j++
+		}
+	}
+	onlyA = append(onlyA, a[i:]...)
+	onlyB = append(onlyB, b[j:]...)
+	return onlyA, onlyB
+}
@@ -0,0 +1,104 @@
+package harmony
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"time"
+)
+
+// NodeStatus is the head of a node and how far it is behind the most advanced node
+type NodeStatus struct {
+	URL         string `json:"url"`
+	ShardID     int64  `json:"shardID"`
+	BlockNumber int64  `json:"blockNumber"`
+	BlockHash   string `json:"blockHash"`
+	Unixtime    int64  `json:"unixtime"`
+	Syncing     bool   `json:"syncing"`
+	Latency     string `json:"latency"`
+	// BlockLag and TimeLag are the blocks and seconds behind the most advanced node
+	BlockLag int64 `json:"blockLag"`
+	TimeLag  int64 `json:"timeLag"`
+	// Stale is set when the node should not receive traffic, Reason tells why
+	Stale  bool   `json:"stale"`
+	Reason string `json:"reason,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// GetNodeStatus fetches the latest header and syncing state of the node at url
+func GetNodeStatus(url string) NodeStatus {
+	status := NodeStatus{URL: url}
+	start := time.Now()
+	var header NetworkHeader
+	if _, err := CallMethod(url, methods.METHOD_protocol_lastestHeader, nil, &header); err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.Latency = time.Since(start).String()
+	status.ShardID = header.ShardID
+	status.BlockNumber = header.BlockNumber
+	status.BlockHash = header.BlockHash
+	status.Unixtime = header.Unixtime
+
+	// hmy_syncing returns false, or an object describing the sync progress
+	var syncing json.RawMessage
+	if _, err := CallMethod(url, methods.METHOD_protocol_syncing, nil, &syncing); err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.Syncing = len(syncing) > 0 && !bytes.Equal(syncing, []byte("false"))
+	return status
+}
+
+// CompareNodeStatuses computes the lag of every node behind the most advanced one
+// Nodes that failed, are syncing, serve another shard or lag more than maxBlockLag or maxTimeLag are marked stale
+func CompareNodeStatuses(statuses []NodeStatus, maxBlockLag int64, maxTimeLag time.Duration) {
+	shardIDs := map[int64]int{}
+	for _, status := range statuses {
+		if status.Error == "" {
+			shardIDs[status.ShardID]++
+		}
+	}
+	// The shard served by most nodes is the one being monitored, ties go to the lowest shard ID
+	var shardID int64
+	found := false
+	for id, count := range shardIDs {
+		if !found || count > shardIDs[shardID] || (count == shardIDs[shardID] && id < shardID) {
+			shardID, found = id, true
+		}
+	}
+	// Shards are at different heights, so the most advanced node is only searched for in the monitored shard
+	var best *NodeStatus
+	for i := range statuses {
+		status := &statuses[i]
+		if status.Error != "" || status.ShardID != shardID {
+			continue
+		}
+		if best == nil || status.BlockNumber > best.BlockNumber {
+			best = status
+		}
+	}
+
+	for i := range statuses {
+		status := &statuses[i]
+		switch {
+		case status.Error != "":
+			status.Stale, status.Reason = true, "unreachable"
+			continue
+		case status.ShardID != shardID:
+			status.Stale, status.Reason = true, fmt.Sprintf("serves shard %d", status.ShardID)
+			continue
+		}
+		status.BlockLag = best.BlockNumber - status.BlockNumber
+		status.TimeLag = best.Unixtime - status.Unixtime
+		switch {
+		case status.Syncing:
+			status.Stale, status.Reason = true, "syncing"
+		case status.BlockLag > maxBlockLag:
+			status.Stale, status.Reason = true, fmt.Sprintf("%d blocks behind", status.BlockLag)
+		case time.Duration(status.TimeLag)*time.Second > maxTimeLag:
+			status.Stale, status.Reason = true, fmt.Sprintf("%ds behind", status.TimeLag)
+		}
+	}
+}
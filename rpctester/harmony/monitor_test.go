@@ -0,0 +1,81 @@
+package harmony
+
+import (
+	"testing"
+	"time"
+)
+
+// TestCompareNodeStatuses only compares statuses, but like every test in the package it needs the .env and a reachable NET_URL for the package init
+func TestCompareNodeStatuses(t *testing.T) {
+	type expectation struct {
+		stale    bool
+		reason   string
+		blockLag int64
+	}
+	type testcase struct {
+		name     string
+		statuses []NodeStatus
+		expected []expectation
+	}
+
+	testCases := []testcase{
+		{
+			name: "in_sync",
+			statuses: []NodeStatus{
+				{URL: "a", BlockNumber: 100, Unixtime: 1000},
+				{URL: "b", BlockNumber: 99, Unixtime: 998},
+			},
+			expected: []expectation{{blockLag: 0}, {blockLag: 1}},
+		}, {
+			name: "lagging_node",
+			statuses: []NodeStatus{
+				{URL: "a", BlockNumber: 100, Unixtime: 1000},
+				{URL: "b", BlockNumber: 80, Unixtime: 990},
+			},
+			expected: []expectation{{blockLag: 0}, {stale: true, reason: "20 blocks behind", blockLag: 20}},
+		}, {
+			name: "time_lag",
+			statuses: []NodeStatus{
+				{URL: "a", BlockNumber: 100, Unixtime: 1000},
+				{URL: "b", BlockNumber: 98, Unixtime: 900},
+			},
+			expected: []expectation{{blockLag: 0}, {stale: true, reason: "100s behind", blockLag: 2}},
+		}, {
+			name: "node_on_another_shard",
+			statuses: []NodeStatus{
+				{URL: "a", ShardID: 1, BlockNumber: 5000, Unixtime: 1000},
+				{URL: "b", BlockNumber: 100, Unixtime: 1000},
+				{URL: "c", BlockNumber: 97, Unixtime: 994},
+			},
+			expected: []expectation{{stale: true, reason: "serves shard 1"}, {blockLag: 0}, {blockLag: 3}},
+		}, {
+			name: "shard_tie_goes_to_lowest_id",
+			statuses: []NodeStatus{
+				{URL: "a", ShardID: 2, BlockNumber: 5000, Unixtime: 1000},
+				{URL: "b", ShardID: 1, BlockNumber: 100, Unixtime: 1000},
+			},
+			expected: []expectation{{stale: true, reason: "serves shard 2"}, {blockLag: 0}},
+		}, {
+			name: "unreachable_and_syncing",
+			statuses: []NodeStatus{
+				{URL: "a", Error: "connection refused"},
+				{URL: "b", BlockNumber: 100, Unixtime: 1000},
+				{URL: "c", BlockNumber: 100, Unixtime: 1000, Syncing: true},
+			},
+			expected: []expectation{{stale: true, reason: "unreachable"}, {blockLag: 0}, {stale: true, reason: "syncing"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			CompareNodeStatuses(tc.statuses, 10, 30*time.Second)
+			for i, status := range tc.statuses {
+				want := tc.expected[i]
+				if status.Stale != want.stale || status.Reason != want.reason || status.BlockLag != want.blockLag {
+					t.Errorf("node %s is stale %t %q lag %d, expected stale %t %q lag %d",
+						status.URL, status.Stale, status.Reason, status.BlockLag, want.stale, want.reason, want.blockLag)
+				}
+			}
+		})
+	}
+}
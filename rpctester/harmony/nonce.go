@@ -0,0 +1,106 @@
+package harmony
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	nonceManagersLock sync.Mutex
+	nonceManagers     = map[common.Address]*NonceManager{}
+)
+
+// NonceManager hands out nonces for an account locally, so that concurrent senders
+// never reuse a nonce. Nonces that were never sent can be released and are handed out again
+type NonceManager struct {
+	sync.Mutex
+	address common.Address
+	synced  bool
+	next    uint64
+	// released holds nonces that were handed out but never sent, they are reused before next
+	released []uint64
+}
+
+// GetNonceManager returns the shared NonceManager for address
+func GetNonceManager(address common.Address) *NonceManager {
+	nonceManagersLock.Lock()
+	defer nonceManagersLock.Unlock()
+	nm, ok := nonceManagers[address]
+	if !ok {
+		nm = &NonceManager{address: address}
+		nonceManagers[address] = nm
+	}
+	return nm
+}
+
+// Next returns the nonce to use for the next transaction
+// The first call will sync the nonce with the pending nonce of the network
+func (nm *NonceManager) Next() (uint64, error) {
+	nm.Lock()
+	defer nm.Unlock()
+	if !nm.synced {
+		if err := nm.resync(); err != nil {
+			return 0, err
+		}
+	}
+	if len(nm.released) > 0 {
+		nonce := nm.released[0]
+		nm.released = nm.released[1:]
+		return nonce, nil
+	}
+	nonce := nm.next
+	nm.next++
+	return nonce, nil
+}
+
+// Release is used when a nonce from Next was never sent, the nonce will be handed out again
+func (nm *NonceManager) Release(nonce uint64) {
+	nm.Lock()
+	defer nm.Unlock()
+	if nonce >= nm.next {
+		return
+	}
+	for _, released := range nm.released {
+		if released == nonce {
+			return
+		}
+	}
+	nm.released = append(nm.released, nonce)
+	sort.Slice(nm.released, func(i, j int) bool { return nm.released[i] < nm.released[j] })
+}
+
+// Resync drops the local state and fetches the pending nonce from the network
+// This should be done after a transaction has been rejected
+func (nm *NonceManager) Resync() error {
+	nm.Lock()
+	defer nm.Unlock()
+	return nm.resync()
+}
+
+func (nm *NonceManager) resync() error {
+	nonce, err := ethClient.PendingNonceAt(context.Background(), nm.address)
+	if err != nil {
+		return err
+	}
+	nm.next = nonce
+	nm.released = nil
+	nm.synced = true
+	return nil
+}
+
+// DetectGap compares the pending nonce of the network with the nonces handed out
+// If the network is waiting for a lower nonce than the next one to hand out, transactions after it
+// will be stuck in the pool. The nonce the network waits for is returned together with true if there is a gap.
+// Transactions that are still being sent will show up as a gap, so only use this when no transactions are in flight.
+func (nm *NonceManager) DetectGap() (uint64, bool, error) {
+	nm.Lock()
+	defer nm.Unlock()
+	pending, err := ethClient.PendingNonceAt(context.Background(), nm.address)
+	if err != nil {
+		return 0, false, err
+	}
+	return pending, nm.synced && pending < nm.next, nil
+}
@@ -0,0 +1,82 @@
+package harmony
+
+import (
+	"fmt"
+	"math/big"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// test_concurrentTransactions sends transactions concurrently from the same account
+// every transaction should get an unique nonce and be accepted
+func (ts *testSuite) test_concurrentTransactions(t *testing.T) {
+	type testcase struct {
+		name         string
+		transactions int
+	}
+
+	testCases := []testcase{
+		{
+			name:         fmt.Sprintf("%s_concurrent_senders", t.Name()),
+			transactions: 5,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			addr := common.HexToAddress(TestAddress)
+			var (
+				wg   sync.WaitGroup
+				lock sync.Mutex
+				errs []error
+			)
+			for i := 0; i < tc.transactions; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					rlp, err := CreateRLPString(addr, addr, *big.NewInt(1), nil)
+					if err == nil {
+						_, err = SendRawTransaction(rlp)
+					}
+					if err != nil {
+						lock.Lock()
+						errs = append(errs, err)
+						lock.Unlock()
+					}
+				}()
+			}
+			wg.Wait()
+
+			if len(errs) > 0 {
+				GetNonceManager(addr).Resync()
+				testMetrics = append(testMetrics, TestMetric{
+					Method: methods.METHOD_transaction_sendRawTransaction,
+					Test:   tc.name,
+					Pass:   false,
+					Error:  fmt.Sprintf("%d of %d transactions rejected: %v", len(errs), tc.transactions, errs[0]),
+				})
+				t.Errorf("%d of %d transactions rejected: %v", len(errs), tc.transactions, errs)
+				return
+			}
+			if pending, gap, err := GetNonceManager(addr).DetectGap(); err != nil || gap {
+				testMetrics = append(testMetrics, TestMetric{
+					Method: methods.METHOD_transaction_sendRawTransaction,
+					Test:   tc.name,
+					Pass:   false,
+					Error:  fmt.Sprintf("nonce gap at %d: %v", pending, err),
+				})
+				t.Errorf("nonce gap at %d: %v", pending, err)
+				return
+			}
+
+			testMetrics = append(testMetrics, TestMetric{
+				Method: methods.METHOD_transaction_sendRawTransaction,
+				Test:   tc.name,
+				Pass:   true,
+			})
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package harmony
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ProfileFile returns the .env file selected by RPCTESTER_ENVIRONMENT
+func ProfileFile() string {
+	switch os.Getenv("RPCTESTER_ENVIRONMENT") {
+	case "production":
+		return ".env-prod"
+	case "dev":
+		return ".env-dev"
+	case "test":
+		return ".env-test"
+	default:
+		return ".env"
+	}
+}
+
+// UpdateProfile sets the values in the active profile file
+// Existing keys are replaced in place so comments and ordering are kept, new keys are appended
+func UpdateProfile(values map[string]string) error {
+	path := ProfileFile()
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	remaining := make(map[string]string, len(values))
+	for key, value := range values {
+		remaining[key] = value
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	for i, line := range lines {
+		key := profileKey(line)
+		value, ok := remaining[key]
+		if !ok {
+			continue
+		}
+		lines[i] = fmt.Sprintf("%s=%q", key, value)
+		delete(remaining, key)
+	}
+
+	keys := make([]string, 0, len(remaining))
+	for key := range remaining {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		lines = append(lines, fmt.Sprintf("%s=%q", key, remaining[key]))
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), info.Mode()); err != nil {
+		return err
+	}
+	for key, value := range values {
+		os.Setenv(key, value)
+	}
+	return nil
+}
+
+// profileKey returns the key assigned on a line of a profile, or an empty string
+func profileKey(line string) string {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ""
+	}
+	line = strings.TrimPrefix(line, "export ")
+	index := strings.Index(line, "=")
+	if index < 0 {
+		return ""
+	}
+	return strings.TrimSpace(line[:index])
+}
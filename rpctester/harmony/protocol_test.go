@@ -2,14 +2,13 @@ package harmony
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"math/big"
 	"percybolmer/rpc-shard-testing/rpctester/methods"
 	"testing"
 )
 
-func (ts *testSuite) test_getSuperCommitees(t *testing.T) {
+func test_getSuperCommittees(t *testing.T) {
 	type testcase struct {
 		name              string
 		br                BaseRequest
@@ -19,12 +18,22 @@ func (ts *testSuite) test_getSuperCommitees(t *testing.T) {
 
 	testCases := []testcase{
 		{
-			name:           fmt.Sprintf("%s_get_super_commitees", t.Name()),
+			name:           fmt.Sprintf("%s_v1_get_super_committees", t.Name()),
 			expectedReturn: false,
 			br: BaseRequest{
 				ID:      "1",
 				JsonRPC: "2.0",
-				Method:  methods.METHOD_protocol_V2_getSuperCommitees,
+				Method:  methods.METHOD_protocol_V1_getSuperCommittees,
+				Params:  []interface{}{},
+			},
+		},
+		{
+			name:           fmt.Sprintf("%s_v2_get_super_committees", t.Name()),
+			expectedReturn: false,
+			br: BaseRequest{
+				ID:      "1",
+				JsonRPC: "2.0",
+				Method:  methods.METHOD_protocol_V2_getSuperCommittees,
 				Params:  []interface{}{},
 			},
 		},
@@ -32,13 +41,25 @@ func (ts *testSuite) test_getSuperCommitees(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			var result json.RawMessage
+			var result SuperCommittees
 
 			resp, err := callAndValidateDataType(t, tc.name, tc.expectedErrorCode, tc.br, &result)
 			if err != nil {
 				t.Error(err)
 				return
 			}
+			// Every shard has a committee in the current epoch, and each decider counts its own members
+			if err := result.Current.Validate(); err != nil {
+				testMetrics = append(testMetrics, TestMetric{
+					Method: tc.br.Method,
+					Test:   tc.name,
+					Pass:   false,
+					Error:  err.Error(),
+					Params: tc.br.Params,
+				})
+				t.Error(err)
+				return
+			}
 
 			testMetrics = append(testMetrics, TestMetric{
 				Method:   tc.br.Method,
@@ -51,6 +72,7 @@ func (ts *testSuite) test_getSuperCommitees(t *testing.T) {
 		})
 	}
 }
+
 func test_isLastBlock(t *testing.T) {
 	type testcase struct {
 		name              string
@@ -207,6 +229,66 @@ func (ts *testSuite) test_latestHeader(t *testing.T) {
 	}
 }
 
+// test_getHeaderByNumber fetches the header returned by latestHeader by its number, both have to be the same header
+func (ts *testSuite) test_getHeaderByNumber(t *testing.T) {
+	if ts.NetworkHeader.BlockHash == "" {
+		t.Skip("Skipping because latestHeader did not return a header")
+	}
+	type testcase struct {
+		name              string
+		br                BaseRequest
+		expectedErrorCode int64
+		expectedReturn    bool
+	}
+
+	testCases := []testcase{
+		{
+			name:           fmt.Sprintf("%s_v2_getHeaderByNumber", t.Name()),
+			expectedReturn: false,
+			br: BaseRequest{
+				ID:      "1",
+				JsonRPC: "2.0",
+				Method:  methods.METHOD_protocol_V2_getHeaderByNumber,
+				Params: []interface{}{
+					ts.NetworkHeader.BlockNumber,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var result NetworkHeader
+
+			resp, err := callAndValidateDataType(t, tc.name, tc.expectedErrorCode, tc.br, &result)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if result.BlockHash != ts.NetworkHeader.BlockHash || result.Epoch != ts.NetworkHeader.Epoch {
+				err := fmt.Errorf("header %d is %s in epoch %d, latestHeader returned %s in epoch %d", result.BlockNumber, result.BlockHash, result.Epoch, ts.NetworkHeader.BlockHash, ts.NetworkHeader.Epoch)
+				testMetrics = append(testMetrics, TestMetric{
+					Method: tc.br.Method,
+					Test:   tc.name,
+					Pass:   false,
+					Error:  err.Error(),
+					Params: tc.br.Params,
+				})
+				t.Error(err)
+				return
+			}
+
+			testMetrics = append(testMetrics, TestMetric{
+				Method:   tc.br.Method,
+				Test:     tc.name,
+				Pass:     true,
+				Duration: resp.Duration,
+				Params:   tc.br.Params,
+			})
+		})
+	}
+}
+
 func test_getShardingStructure(t *testing.T) {
 	type testcase struct {
 		name              string
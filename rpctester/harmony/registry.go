@@ -0,0 +1,89 @@
+package harmony
+
+import (
+	"encoding/json"
+	"math/big"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// resultTypes creates a value for every Go type named as a result in the methods registry
+var resultTypes = map[string]func() interface{}{
+	"string":                      func() interface{} { return new(string) },
+	"bool":                        func() interface{} { return new(bool) },
+	"int64":                       func() interface{} { return new(int64) },
+	"big.Int":                     func() interface{} { return new(big.Int) },
+	"hexutil.Big":                 func() interface{} { return new(hexutil.Big) },
+	"hexutil.Uint64":              func() interface{} { return new(hexutil.Uint64) },
+	"hexutil.Bytes":               func() interface{} { return new(hexutil.Bytes) },
+	"json.RawMessage":             func() interface{} { return new(json.RawMessage) },
+	"[]string":                    func() interface{} { return new([]string) },
+	"[]TransactionLog":            func() interface{} { return new([]TransactionLog) },
+	"StakingTransactionV1":        func() interface{} { return new(StakingTransactionV1) },
+	"StakingTransactionV2":        func() interface{} { return new(StakingTransactionV2) },
+	"[]ErrorSinkLog":              func() interface{} { return new([]ErrorSinkLog) },
+	"[]PendingCXReceipt":          func() interface{} { return new([]PendingCXReceipt) },
+	"RecieptV1":                   func() interface{} { return new(RecieptV1) },
+	"Reciept":                     func() interface{} { return new(Reciept) },
+	"[]TransactionByHashV1":       func() interface{} { return new([]TransactionByHashV1) },
+	"[]TransactionByHashV2":       func() interface{} { return new([]TransactionByHashV2) },
+	"TransactionHistoryV1":        func() interface{} { return new(TransactionHistoryV1) },
+	"TransactionHistoryV2":        func() interface{} { return new(TransactionHistoryV2) },
+	"StakingTransactionHistoryV1": func() interface{} { return new(StakingTransactionHistoryV1) },
+	"StakingTransactionHistoryV2": func() interface{} { return new(StakingTransactionHistoryV2) },
+	"TransactionReceipt_V1":       func() interface{} { return new(TransactionReceipt_V1) },
+	"TransactionReceipt_V2":       func() interface{} { return new(TransactionReceipt_V2) },
+	"TransactionByHashV1":         func() interface{} { return new(TransactionByHashV1) },
+	"TransactionByHashV2":         func() interface{} { return new(TransactionByHashV2) },
+	"BlockV1":                     func() interface{} { return new(BlockV1) },
+	"BlockV2":                     func() interface{} { return new(BlockV2) },
+	"[]BlockV1":                   func() interface{} { return new([]BlockV1) },
+	"[]BlockV2":                   func() interface{} { return new([]BlockV2) },
+	"Transaction":                 func() interface{} { return new(Transaction) },
+	"NetworkHeader":               func() interface{} { return new(NetworkHeader) },
+	"shardingStructure":           func() interface{} { return new(shardingStructure) },
+	"NetworkStakingInfo":          func() interface{} { return new(NetworkStakingInfo) },
+	"[]ValidatorInfo":             func() interface{} { return new([]ValidatorInfo) },
+	"ValidatorInfo":               func() interface{} { return new(ValidatorInfo) },
+	"UtilityMetrics":              func() interface{} { return new(UtilityMetrics) },
+	"[]DelegationByValidator":     func() interface{} { return new([]DelegationByValidator) },
+	"ValidatorMetrics":            func() interface{} { return new(ValidatorMetrics) },
+	"GetValidatorsV1":             func() interface{} { return new(GetValidatorsV1) },
+	"GetValidatorsV2":             func() interface{} { return new(GetValidatorsV2) },
+	"MedianRawStakeSnapshot":      func() interface{} { return new(MedianRawStakeSnapshot) },
+	"SuperCommittees":             func() interface{} { return new(SuperCommittees) },
+	"[]TraceBlock":                func() interface{} { return new([]TraceBlock) },
+	"CallFrame":                   func() interface{} { return new(CallFrame) },
+	"EthBlock":                    func() interface{} { return new(EthBlock) },
+	"EthTransaction":              func() interface{} { return new(EthTransaction) },
+	"EthReceipt":                  func() interface{} { return new(EthReceipt) },
+}
+
+// NewResult returns a pointer to the Go type the result of method decodes into
+// Unknown methods and types fall back to a json.RawMessage
+func NewResult(method string) interface{} {
+	if m, ok := methods.Lookup(method); ok {
+		if create, ok := resultTypes[m.Result]; ok {
+			return create()
+		}
+	}
+	return new(json.RawMessage)
+}
+
+// UncoveredMethods returns the registered methods that no metric was recorded for, sorted by name
+func UncoveredMethods(metrics []TestMetric) []string {
+	covered := map[string]bool{}
+	for _, metric := range metrics {
+		covered[metric.Method] = true
+	}
+	uncovered := []string{}
+	for name := range methods.Registry {
+		if !covered[name] {
+			uncovered = append(uncovered, name)
+		}
+	}
+	sort.Strings(uncovered)
+	return uncovered
+}
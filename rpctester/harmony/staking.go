@@ -0,0 +1,464 @@
+package harmony
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	METRIC_stakingConsistency = "staking_consistency"
+
+	// NetworkWide holds the inconsistencies of a StakingReport that do not belong to a single validator
+	NetworkWide = "network"
+)
+
+var (
+	// eposMargin is how far an effective stake may be from the median, stakes are clamped to median * (1 ± eposMargin)
+	eposMargin = big.NewRat(15, 100)
+	// decTolerance absorbs the rounding of the 18 decimal numbers that EPoS stakes are computed with
+	decTolerance = big.NewRat(1, 100000000000000000)
+)
+
+// StakeDec is an 18 decimal number such as "1000.000000000000000000", used for EPoS stakes
+type StakeDec struct {
+	big.Rat
+}
+
+// UnmarshalJSON accepts the decimal quoted or as a number
+func (d *StakeDec) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(bytes.TrimSpace(data)), `"`)
+	if s == "" || s == "null" {
+		return nil
+	}
+	if _, ok := d.SetString(s); !ok {
+		return fmt.Errorf("invalid decimal %s", data)
+	}
+	return nil
+}
+
+// MedianRawStakeSnapshot is the EPoS auction for the next epoch, computed from the current stakes
+type MedianRawStakeSnapshot struct {
+	MedianStake      StakeDec        `json:"epos-median-stake"`
+	MaxExternalSlots int             `json:"max-external-slots"`
+	Winners          []SlotWinner    `json:"epos-slot-winners"`
+	Candidates       []SlotCandidate `json:"epos-slot-candidates"`
+}
+
+// SlotWinner is a BLS key that won a slot, the raw stake is the stake of the validator spread over its keys
+type SlotWinner struct {
+	Owner     string   `json:"slot-owner"`
+	Key       string   `json:"bls-public-key"`
+	RawStake  StakeDec `json:"raw-stake"`
+	EPoSStake StakeDec `json:"eposed-stake"`
+}
+
+// SlotCandidate is a validator taking part in the auction
+type SlotCandidate struct {
+	Validator   string      `json:"validator"`
+	Stake       ExplorerBig `json:"stake"`
+	StakePerKey ExplorerBig `json:"stake-per-key"`
+	Keys        []string    `json:"keys-at-auction"`
+}
+
+// StakingReport holds the inconsistencies found between the staking methods, by validator address
+type StakingReport struct {
+	Validators      []string            `json:"validators"`
+	Delegators      int                 `json:"delegators"`
+	Inconsistencies map[string][]string `json:"inconsistencies"`
+}
+
+func (r *StakingReport) add(validator string, format string, args ...interface{}) {
+	r.Inconsistencies[validator] = append(r.Inconsistencies[validator], fmt.Sprintf(format, args...))
+}
+
+// String lists the inconsistencies, one line per inconsistency
+func (r *StakingReport) String() string {
+	if len(r.Inconsistencies) == 0 {
+		return fmt.Sprintf("%d validators and %d delegators are consistent", len(r.Validators), r.Delegators)
+	}
+	keys := make([]string, 0, len(r.Inconsistencies))
+	for key := range r.Inconsistencies {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	for _, key := range keys {
+		for _, inconsistency := range r.Inconsistencies[key] {
+			fmt.Fprintf(&sb, "%s: %s\n", key, inconsistency)
+		}
+	}
+	return sb.String()
+}
+
+// GetAddressList calls a staking method that returns a list of addresses
+func GetAddressList(method string) ([]string, error) {
+	var result []string
+	_, err := CallMethod(URL, method, nil, &result)
+	return result, err
+}
+
+// GetValidatorInformation returns hmy_getValidatorInformation of validator
+func GetValidatorInformation(validator string) (*ValidatorInfo, error) {
+	var result ValidatorInfo
+	if _, err := CallMethod(URL, methods.METHOD_staking_getValidatorInformation, []interface{}{validator}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetDelegations returns the delegations of address through hmy_getDelegationsByValidator or hmy_getDelegationsByDelegator
+func GetDelegations(method string, address string) ([]DelegationByValidator, error) {
+	var result []DelegationByValidator
+	_, err := CallMethod(URL, method, []interface{}{address}, &result)
+	return result, err
+}
+
+// GetMedianRawStakeSnapshot returns hmy_getMedianRawStakeSnapshot
+func GetMedianRawStakeSnapshot() (*MedianRawStakeSnapshot, error) {
+	var result MedianRawStakeSnapshot
+	if _, err := CallMethod(URL, methods.METHOD_staking_getMedianRawStakeSnapshot, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetEpoch returns the current epoch
+func GetEpoch() (int64, error) {
+	var epoch int64
+	_, err := CallMethod(URL, methods.METHOD_protocol_V2_getEpoch, nil, &epoch)
+	return epoch, err
+}
+
+// CheckStakingConsistency compares what the staking methods report about the same validators and delegations
+// Elected validators are checked first, maxValidators limits how many are checked, 0 checks all of them
+// An error is only returned if a method fails, inconsistencies are collected in the report
+func CheckStakingConsistency(maxValidators int) (*StakingReport, error) {
+	report := &StakingReport{Inconsistencies: map[string][]string{}}
+	lists := make(map[string][]string)
+	for _, method := range []string{
+		methods.METHOD_staking_V2_getAllValidatorAddresses,
+		methods.METHOD_staking_getActiveValidatorAddresses,
+		methods.METHOD_staking_V1_getElectedValidatorAddresses,
+		methods.METHOD_staking_V2_getElectedValidatorAddresses,
+	} {
+		addresses, err := GetAddressList(method)
+		if err != nil {
+			return nil, err
+		}
+		lists[method] = addresses
+	}
+	active := addressSet(lists[methods.METHOD_staking_getActiveValidatorAddresses])
+	elected := addressSet(lists[methods.METHOD_staking_V2_getElectedValidatorAddresses])
+	checkElected(report, addressSet(lists[methods.METHOD_staking_V1_getElectedValidatorAddresses]), elected, active)
+
+	report.Validators = selectValidators(maxValidators, lists[methods.METHOD_staking_V2_getElectedValidatorAddresses], lists[methods.METHOD_staking_V2_getAllValidatorAddresses])
+	infos := make(map[string]*ValidatorInfo, len(report.Validators))
+	delegations := make(map[string]map[string]*big.Int, len(report.Validators))
+	for _, validator := range report.Validators {
+		info, err := GetValidatorInformation(validator)
+		if err != nil {
+			return nil, err
+		}
+		byValidator, err := GetDelegations(methods.METHOD_staking_getDelegationsByValidator, validator)
+		if err != nil {
+			return nil, err
+		}
+		infos[validator] = info
+		delegations[validator] = checkValidatorDelegations(report, validator, info, byValidator, elected[validator])
+	}
+
+	if err := checkDelegators(report, delegations); err != nil {
+		return nil, err
+	}
+	if err := checkValidatorBalances(report); err != nil {
+		return nil, err
+	}
+	if err := checkMedianSnapshot(report, infos, active); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// normalizeAddress returns the bech32 form of a bech32 or hex address, so that addresses compare equal
+func normalizeAddress(address string) string {
+	return ToBech32(Parse(address))
+}
+
+func addressSet(addresses []string) map[string]bool {
+	set := make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		set[normalizeAddress(address)] = true
+	}
+	return set
+}
+
+// selectValidators puts the elected validators first and limits the list to max
+func selectValidators(max int, elected []string, all []string) []string {
+	seen := make(map[string]bool)
+	var selected []string
+	for _, address := range append(append([]string{}, elected...), all...) {
+		address = normalizeAddress(address)
+		if seen[address] {
+			continue
+		}
+		seen[address] = true
+		selected = append(selected, address)
+	}
+	if max > 0 && len(selected) > max {
+		selected = selected[:max]
+	}
+	return selected
+}
+
+// checkElected verifies that both versions of getElectedValidatorAddresses agree and only hold active validators
+func checkElected(report *StakingReport, electedV1, electedV2, active map[string]bool) {
+	for address := range electedV2 {
+		if !electedV1[address] {
+			report.add(address, "elected in %s but not in %s", methods.METHOD_staking_V2_getElectedValidatorAddresses, methods.METHOD_staking_V1_getElectedValidatorAddresses)
+		}
+		if !active[address] {
+			report.add(address, "elected but missing from %s", methods.METHOD_staking_getActiveValidatorAddresses)
+		}
+	}
+	for address := range electedV1 {
+		if !electedV2[address] {
+			report.add(address, "elected in %s but not in %s", methods.METHOD_staking_V1_getElectedValidatorAddresses, methods.METHOD_staking_V2_getElectedValidatorAddresses)
+		}
+	}
+}
+
+// checkValidatorDelegations compares the delegations of a validator with its total delegation
+// The delegations are returned by delegator address for checkDelegators
+func checkValidatorDelegations(report *StakingReport, validator string, info *ValidatorInfo, byValidator []DelegationByValidator, elected bool) map[string]*big.Int {
+	if address := normalizeAddress(info.Validator.Address); address != validator {
+		report.add(validator, "getValidatorInformation returned validator %s", address)
+	}
+	amounts := make(map[string]*big.Int, len(byValidator))
+	sum := big.NewInt(0)
+	for i := range byValidator {
+		delegation := byValidator[i]
+		if address := normalizeAddress(delegation.ValidatorAddress); address != validator {
+			report.add(validator, "getDelegationsByValidator holds a delegation to %s", address)
+		}
+		delegator := normalizeAddress(delegation.DelegatorAddress)
+		if _, ok := amounts[delegator]; ok {
+			report.add(validator, "getDelegationsByValidator holds %s twice", delegator)
+		}
+		amounts[delegator] = &delegation.Amount
+		sum.Add(sum, &delegation.Amount)
+	}
+	if sum.Cmp(&info.TotalDelegation) != 0 {
+		report.add(validator, "getDelegationsByValidator sums to %s but total-delegation is %s", sum, &info.TotalDelegation)
+	}
+	infoSum := big.NewInt(0)
+	for i := range info.Validator.Delegations {
+		infoSum.Add(infoSum, &info.Validator.Delegations[i].Amount)
+	}
+	if infoSum.Cmp(&info.TotalDelegation) != 0 {
+		report.add(validator, "the delegations in getValidatorInformation sum to %s but total-delegation is %s", infoSum, &info.TotalDelegation)
+	}
+	if elected && !info.CurrentlyInCommittee {
+		report.add(validator, "elected but getValidatorInformation reports it is not in the committee, epos-status is %q", info.EPoSStatus)
+	}
+	return amounts
+}
+
+// checkDelegators fetches getDelegationsByDelegator for every delegator of the checked validators
+// and compares it with getDelegationsByValidator, both ways
+func checkDelegators(report *StakingReport, delegations map[string]map[string]*big.Int) error {
+	delegators := make(map[string]bool)
+	for _, amounts := range delegations {
+		for delegator := range amounts {
+			delegators[delegator] = true
+		}
+	}
+	report.Delegators = len(delegators)
+	for delegator := range delegators {
+		byDelegator, err := GetDelegations(methods.METHOD_staking_getDelegationsByDelegator, delegator)
+		if err != nil {
+			return err
+		}
+		found := make(map[string]bool, len(byDelegator))
+		for i := range byDelegator {
+			delegation := byDelegator[i]
+			validator := normalizeAddress(delegation.ValidatorAddress)
+			if address := normalizeAddress(delegation.DelegatorAddress); address != delegator {
+				report.add(validator, "getDelegationsByDelegator of %s holds a delegation from %s", delegator, address)
+			}
+			amounts, checked := delegations[validator]
+			if !checked {
+				continue
+			}
+			found[validator] = true
+			amount, ok := amounts[delegator]
+			if !ok {
+				report.add(validator, "getDelegationsByDelegator of %s holds %s that getDelegationsByValidator does not", delegator, &delegation.Amount)
+				continue
+			}
+			if amount.Cmp(&delegation.Amount) != 0 {
+				report.add(validator, "%s delegates %s in getDelegationsByValidator but %s in getDelegationsByDelegator", delegator, amount, &delegation.Amount)
+			}
+		}
+		for validator, amounts := range delegations {
+			if _, ok := amounts[delegator]; ok && !found[validator] {
+				report.add(validator, "the delegation from %s is missing from getDelegationsByDelegator", delegator)
+			}
+		}
+	}
+	return nil
+}
+
+// checkValidatorBalances compares the committee and balances of both versions of getValidators for the current epoch
+func checkValidatorBalances(report *StakingReport) error {
+	epoch, err := GetEpoch()
+	if err != nil {
+		return err
+	}
+	var v1 GetValidatorsV1
+	if _, err := CallMethod(URL, methods.METHOD_staking_V1_getValidators, []interface{}{epoch}, &v1); err != nil {
+		return err
+	}
+	var v2 GetValidatorsV2
+	if _, err := CallMethod(URL, methods.METHOD_staking_V2_getValidators, []interface{}{epoch}, &v2); err != nil {
+		return err
+	}
+	if v1.ShardID != v2.ShardID {
+		report.add(NetworkWide, "getValidators is for shard %d in V1 but shard %d in V2", v1.ShardID, v2.ShardID)
+	}
+	if len(v1.Validators) != len(v2.Validators) {
+		report.add(NetworkWide, "getValidators holds %d slots in V1 but %d in V2 for epoch %d", len(v1.Validators), len(v2.Validators), epoch)
+	}
+	balances := make(map[string]*big.Int, len(v2.Validators))
+	for i := range v2.Validators {
+		balances[normalizeAddress(v2.Validators[i].Address)] = &v2.Validators[i].Balance
+	}
+	for _, validator := range v1.Validators {
+		address := normalizeAddress(validator.Address)
+		balance, ok := balances[address]
+		if !ok {
+			report.add(address, "in the V1 getValidators committee of epoch %d but not in V2", epoch)
+			continue
+		}
+		v1Balance, err := hexutil.DecodeBig(validator.Balance)
+		if err != nil {
+			report.add(address, "V1 getValidators balance %q: %v", validator.Balance, err)
+			continue
+		}
+		if v1Balance.Cmp(balance) != 0 {
+			report.add(address, "getValidators balance is %s in V1 but %s in V2", v1Balance, balance)
+		}
+	}
+	return nil
+}
+
+// checkMedianSnapshot recomputes the median and effective stakes of the auction winners and compares the
+// candidate stakes with the total delegation of the checked validators
+func checkMedianSnapshot(report *StakingReport, infos map[string]*ValidatorInfo, active map[string]bool) error {
+	snapshot, err := GetMedianRawStakeSnapshot()
+	if err != nil {
+		return err
+	}
+	if len(snapshot.Winners) > snapshot.MaxExternalSlots {
+		report.add(NetworkWide, "%d slot winners but only %d external slots", len(snapshot.Winners), snapshot.MaxExternalSlots)
+	}
+	raw := make([]*big.Rat, len(snapshot.Winners))
+	for i := range snapshot.Winners {
+		raw[i] = &snapshot.Winners[i].RawStake.Rat
+	}
+	median := MedianStake(raw)
+	if !withinTolerance(median, &snapshot.MedianStake.Rat) {
+		report.add(NetworkWide, "epos-median-stake is %s but the median of the winners raw stake is %s", snapshot.MedianStake.FloatString(18), median.FloatString(18))
+		median = &snapshot.MedianStake.Rat
+	}
+
+	candidates := make(map[string]SlotCandidate, len(snapshot.Candidates))
+	for _, candidate := range snapshot.Candidates {
+		validator := normalizeAddress(candidate.Validator)
+		candidates[validator] = candidate
+		if !active[validator] {
+			report.add(validator, "auction candidate but missing from %s", methods.METHOD_staking_getActiveValidatorAddresses)
+		}
+		if len(candidate.Keys) > 0 {
+			perKey := new(big.Int).Div(&candidate.Stake.Int, big.NewInt(int64(len(candidate.Keys))))
+			if perKey.Cmp(&candidate.StakePerKey.Int) != 0 {
+				report.add(validator, "stake-per-key is %s but stake %s over %d keys is %s", &candidate.StakePerKey.Int, &candidate.Stake.Int, len(candidate.Keys), perKey)
+			}
+		}
+		if info, ok := infos[validator]; ok && candidate.Stake.Cmp(&info.TotalDelegation) != 0 {
+			report.add(validator, "stakes %s in getMedianRawStakeSnapshot but total-delegation is %s", &candidate.Stake.Int, &info.TotalDelegation)
+		}
+	}
+
+	for _, winner := range snapshot.Winners {
+		validator := normalizeAddress(winner.Owner)
+		if effective := EffectiveStake(median, &winner.RawStake.Rat); !withinTolerance(effective, &winner.EPoSStake.Rat) {
+			report.add(validator, "slot %s has eposed-stake %s but raw stake %s clamped to the median is %s", winner.Key, winner.EPoSStake.FloatString(18), winner.RawStake.FloatString(18), effective.FloatString(18))
+		}
+		candidate, ok := candidates[validator]
+		if !ok {
+			report.add(validator, "slot %s won but its validator is not an auction candidate", winner.Key)
+			continue
+		}
+		if !containsKey(candidate.Keys, winner.Key) {
+			report.add(validator, "slot %s won but the key is not at auction", winner.Key)
+			continue
+		}
+		spread := new(big.Rat).SetFrac(&candidate.Stake.Int, big.NewInt(int64(len(candidate.Keys))))
+		if !withinTolerance(spread, &winner.RawStake.Rat) {
+			report.add(validator, "slot %s has raw-stake %s but the stake spread over %d keys is %s", winner.Key, winner.RawStake.FloatString(18), len(candidate.Keys), spread.FloatString(18))
+		}
+	}
+	return nil
+}
+
+// MedianStake is the median of the raw stakes of the winning slots, as computed by the EPoS auction
+func MedianStake(stakes []*big.Rat) *big.Rat {
+	if len(stakes) == 0 {
+		return new(big.Rat)
+	}
+	sorted := append([]*big.Rat{}, stakes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Cmp(sorted[j]) > 0 })
+	l := len(sorted)
+	if l%2 == 1 {
+		return new(big.Rat).Set(sorted[l/2])
+	}
+	sum := new(big.Rat).Add(sorted[l/2-1], sorted[l/2])
+	return sum.Quo(sum, big.NewRat(2, 1))
+}
+
+// EffectiveStake clamps a raw stake to median * (1 ± eposMargin)
+func EffectiveStake(median, raw *big.Rat) *big.Rat {
+	one := big.NewRat(1, 1)
+	high := new(big.Rat).Mul(median, new(big.Rat).Add(one, eposMargin))
+	low := new(big.Rat).Mul(median, new(big.Rat).Sub(one, eposMargin))
+	switch {
+	case raw.Cmp(high) > 0:
+		return high
+	case raw.Cmp(low) < 0:
+		return low
+	default:
+		return new(big.Rat).Set(raw)
+	}
+}
+
+func withinTolerance(a, b *big.Rat) bool {
+	diff := new(big.Rat).Sub(a, b)
+	return diff.Abs(diff).Cmp(decTolerance) <= 0
+}
+
+// containsKey compares BLS keys with or without 0x and in any case
+func containsKey(keys []string, key string) bool {
+	normalize := func(k string) string { return strings.TrimPrefix(strings.ToLower(k), "0x") }
+	for _, k := range keys {
+		if normalize(k) == normalize(key) {
+			return true
+		}
+	}
+	return false
+}
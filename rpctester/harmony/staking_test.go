@@ -1,12 +1,20 @@
 package harmony
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
+	"percybolmer/rpc-shard-testing/rpctester/crypto"
 	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 )
 
+// maxConsistencyValidators limits how many validators test_stakingConsistency checks, each costs a call per delegator
+const maxConsistencyValidators = 50
+
 func test_getCirculatingSupply(t *testing.T) {
 	type testcase struct {
 		name              string
@@ -518,7 +526,7 @@ func test_getMedianRawStakeSnapshot(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			var result string
+			var result MedianRawStakeSnapshot
 
 			resp, err := callAndValidateDataType(t, tc.name, tc.expectedErrorCode, tc.br, &result)
 			if err != nil {
@@ -1135,3 +1143,51 @@ func (ts *testSuite) test_V1_getAllElectedValidators(t *testing.T) {
 		})
 	}
 }
+
+// test_stakingConsistency compares the delegations, elected and active sets, getValidators balances and the median stake
+// snapshot across the staking methods. Every validator with an inconsistency fails its own subtest
+func test_stakingConsistency(t *testing.T) {
+	if shardID, err := crypto.GetShardID(); err == nil && shardID != 0 {
+		t.Skip("Staking information is only served by the beacon shard")
+	}
+	start := time.Now()
+	report, err := CheckStakingConsistency(maxConsistencyValidators)
+	if err != nil {
+		failMetric(t, METRIC_stakingConsistency, err)
+		return
+	}
+	duration := time.Since(start).String()
+	t.Logf("checked %d validators and %d delegators", len(report.Validators), report.Delegators)
+
+	// Inconsistencies can be found for validators outside of the checked ones, such as auction candidates
+	addresses := append([]string{NetworkWide}, report.Validators...)
+	for address := range report.Inconsistencies {
+		if address != NetworkWide && !containsString(report.Validators, address) {
+			addresses = append(addresses, address)
+		}
+	}
+	sort.Strings(addresses[1:])
+	for _, address := range addresses {
+		t.Run(address, func(t *testing.T) {
+			if inconsistencies := report.Inconsistencies[address]; len(inconsistencies) > 0 {
+				failMetric(t, METRIC_stakingConsistency, errors.New(strings.Join(inconsistencies, "; ")))
+				return
+			}
+			testMetrics = append(testMetrics, TestMetric{
+				Method:   METRIC_stakingConsistency,
+				Test:     t.Name(),
+				Pass:     true,
+				Duration: duration,
+			})
+		})
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"percybolmer/rpc-shard-testing/rpctester/methods"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -26,8 +27,14 @@ type testSuite struct {
 	LastStakingTransactionHash      string
 	LastStakingTransactionBlockHash string
 
+	LastCrossShardTransactionHash string
+	LastCrossShardToShardID       uint32
+
 	ActiveValidators  []string
 	ElectedValidators []string
+
+	// LastEthTransactionHash is sent through eth_sendRawTransaction
+	LastEthTransactionHash string
 }
 
 func (ts *testSuite) test_sendRawTransaction(t *testing.T) {
@@ -81,6 +88,7 @@ func (ts *testSuite) test_sendRawTransaction(t *testing.T) {
 				t.Error(err)
 				return
 			}
+			submitted := time.Now()
 			resp, err := Call(txdata, tc.br.Method)
 			if err != nil {
 				testMetrics = append(testMetrics, TestMetric{
@@ -95,6 +103,8 @@ func (ts *testSuite) test_sendRawTransaction(t *testing.T) {
 				return
 			}
 			if resp.Error != nil {
+				// The nonce used was never accepted, so fetch it from the network again
+				GetNonceManager(fromAddr).Resync()
 				if resp.Error.Code != tc.expectedErrorCode {
 					testMetrics = append(testMetrics, TestMetric{
 						Method:   tc.br.Method,
@@ -128,6 +138,7 @@ func (ts *testSuite) test_sendRawTransaction(t *testing.T) {
 				}
 				// Store TX hash in TestSuite
 				ts.LastTransactionHash = s
+				TrackSubmission(s, submitted)
 			}
 
 			testMetrics = append(testMetrics, TestMetric{
@@ -0,0 +1,65 @@
+package harmony
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"percybolmer/rpc-shard-testing/rpctester/contracts/tracetarget"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DeployTraceTarget deploys the tracetarget contract and returns its address once it is included
+func DeployTraceTarget(ctx context.Context) (common.Address, error) {
+	from := common.HexToAddress(TestAddress)
+	rlp, err := CreateContractRLPString(from, common.FromHex(tracetarget.TraceTargetBin))
+	if err != nil {
+		return common.Address{}, err
+	}
+	hash, err := SendRawTransaction(rlp)
+	if err != nil {
+		GetNonceManager(from).Resync()
+		return common.Address{}, err
+	}
+	receipt, err := WaitForReceipt(ctx, hash)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if receipt.Status != 1 || !common.IsHexAddress(receipt.ContractAddress) {
+		return common.Address{}, fmt.Errorf("deploying the trace target in %s failed with status %d", hash, receipt.Status)
+	}
+	return common.HexToAddress(receipt.ContractAddress), nil
+}
+
+// TraceTransaction fetches the parity style traces of a transaction using trace_transaction
+func TraceTransaction(hash string) ([]TraceBlock, error) {
+	var traces []TraceBlock
+	if _, err := CallMethod(URL, methods.METHOD_trace_transaction, []interface{}{hash}, &traces); err != nil {
+		return nil, err
+	}
+	return traces, nil
+}
+
+// TraceBlockByNumber fetches the parity style traces of every transaction in block n using trace_block
+func TraceBlockByNumber(n int64) ([]TraceBlock, error) {
+	var traces []TraceBlock
+	if _, err := CallMethod(URL, methods.METHOD_trace_block, []interface{}{n}, &traces); err != nil {
+		return nil, err
+	}
+	return traces, nil
+}
+
+// CallTraceTransaction fetches the call tree of a transaction using debug_traceTransaction and the built in callTracer
+func CallTraceTransaction(hash string) (*CallFrame, error) {
+	var frame *CallFrame
+	config := TraceConfig{Tracer: "callTracer", Timeout: (10 * time.Second).String()}
+	if _, err := CallMethod(URL, methods.METHOD_debug_traceTransaction, []interface{}{hash, config}, &frame); err != nil {
+		return nil, err
+	}
+	if frame == nil {
+		return nil, errors.New("debug_traceTransaction returned no call frame")
+	}
+	return frame, nil
+}
@@ -1,9 +1,18 @@
 package harmony
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"math/big"
+	"percybolmer/rpc-shard-testing/rpctester/contracts/tracetarget"
 	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 func (ts *testSuite) test_traceBlock(t *testing.T) {
@@ -91,3 +100,244 @@ func (ts *testSuite) test_traceTransaction(t *testing.T) {
 		})
 	}
 }
+
+// expectedCall is the shape a call in a trace is expected to have
+// An empty output is not checked
+type expectedCall struct {
+	typ      string
+	reverted bool
+	output   string
+	calls    []expectedCall
+}
+
+// test_traceCallTrees deploys the tracetarget contract, sends transactions with nested calls, CREATE
+// and reverts, and checks the call trees returned by trace_transaction, trace_block and debug_traceTransaction
+func (ts *testSuite) test_traceCallTrees(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	target, err := DeployTraceTarget(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zeroWord := hexutil.Encode(make([]byte, 32))
+
+	type testcase struct {
+		name     string
+		selector byte
+		// gasLimit is set for transactions that revert, since their gas can not be estimated
+		gasLimit uint64
+		expected expectedCall
+	}
+
+	testCases := []testcase{
+		{
+			name:     fmt.Sprintf("%s_nested_call", t.Name()),
+			selector: tracetarget.SelectorNestedCall,
+			expected: expectedCall{typ: "CALL", output: tracetarget.LeafOutput, calls: []expectedCall{
+				{typ: "CALL", output: tracetarget.LeafOutput},
+			}},
+		},
+		{
+			name:     fmt.Sprintf("%s_create", t.Name()),
+			selector: tracetarget.SelectorCreate,
+			expected: expectedCall{typ: "CALL", calls: []expectedCall{
+				{typ: "CREATE"},
+			}},
+		},
+		{
+			name:     fmt.Sprintf("%s_revert", t.Name()),
+			selector: tracetarget.SelectorRevert,
+			gasLimit: 100000,
+			expected: expectedCall{typ: "CALL", reverted: true},
+		},
+		{
+			name:     fmt.Sprintf("%s_nested_revert", t.Name()),
+			selector: tracetarget.SelectorNestedRevert,
+			gasLimit: 100000,
+			expected: expectedCall{typ: "CALL", output: zeroWord, calls: []expectedCall{
+				{typ: "CALL", reverted: true},
+			}},
+		},
+	}
+
+	from := common.HexToAddress(TestAddress)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rlp, err := CreateRLPStringWithGasLimit(target, from, *big.NewInt(0), []byte{tc.selector}, tc.gasLimit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			hash, err := SendRawTransaction(rlp)
+			if err != nil {
+				GetNonceManager(from).Resync()
+				t.Fatal(err)
+			}
+			receipt, err := WaitForReceipt(ctx, hash)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			t.Run("trace_transaction", func(t *testing.T) {
+				start := time.Now()
+				traces, err := TraceTransaction(hash)
+				if err == nil {
+					err = checkParityTraces(traces, hash, tc.expected)
+				}
+				if err != nil {
+					failMetric(t, methods.METHOD_trace_transaction, err)
+					return
+				}
+				passMetric(t, methods.METHOD_trace_transaction, start)
+			})
+			t.Run("trace_block", func(t *testing.T) {
+				start := time.Now()
+				if err := compareBlockTraces(receipt.BlockNumber, hash); err != nil {
+					failMetric(t, methods.METHOD_trace_block, err)
+					return
+				}
+				passMetric(t, methods.METHOD_trace_block, start)
+			})
+			t.Run("debug_traceTransaction", func(t *testing.T) {
+				start := time.Now()
+				frame, err := CallTraceTransaction(hash)
+				if err == nil {
+					err = checkCallFrame(*frame, tc.expected, "call")
+				}
+				if err == nil && hexToBig(frame.GasUsed).Cmp(&receipt.GasUsed) != 0 {
+					err = fmt.Errorf("call used %s gas, the receipt used %s", hexToBig(frame.GasUsed), &receipt.GasUsed)
+				}
+				if err == nil && tc.selector == tracetarget.SelectorCreate {
+					err = checkCreatedAddress(frame.Output, frame.Calls[0].To)
+				}
+				if err != nil {
+					failMetric(t, methods.METHOD_debug_traceTransaction, err)
+					return
+				}
+				passMetric(t, methods.METHOD_debug_traceTransaction, start)
+			})
+		})
+	}
+}
+
+// checkCallFrame compares a callTracer frame with the expected call, path is used in errors
+func checkCallFrame(frame CallFrame, want expectedCall, path string) error {
+	if !strings.EqualFold(frame.Type, want.typ) {
+		return fmt.Errorf("%s is a %s, expected %s", path, frame.Type, want.typ)
+	}
+	if reverted := frame.Error != ""; reverted != want.reverted {
+		return fmt.Errorf("%s reverted is %t, expected %t: %s", path, reverted, want.reverted, frame.Error)
+	}
+	if want.output != "" && !strings.EqualFold(frame.Output, want.output) {
+		return fmt.Errorf("%s returned %s, expected %s", path, frame.Output, want.output)
+	}
+	gas, gasUsed := hexToBig(frame.Gas), hexToBig(frame.GasUsed)
+	if gasUsed.Cmp(gas) > 0 && path != "call" {
+		return fmt.Errorf("%s used %s gas, more than the %s it was given", path, gasUsed, gas)
+	}
+	if len(frame.Calls) != len(want.calls) {
+		return fmt.Errorf("%s made %d calls, expected %d", path, len(frame.Calls), len(want.calls))
+	}
+	for i, call := range frame.Calls {
+		if hexToBig(call.Gas).Cmp(gas) > 0 {
+			return fmt.Errorf("%s.%d was given %s gas, more than its parent had", path, i, hexToBig(call.Gas))
+		}
+		if err := checkCallFrame(call, want.calls[i], fmt.Sprintf("%s.%d", path, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flattenCalls orders the expected calls the way parity traces are, depth first with their trace address
+func flattenCalls(call expectedCall, address []int) ([]expectedCall, [][]int) {
+	calls := []expectedCall{call}
+	addresses := [][]int{address}
+	for i, child := range call.calls {
+		childAddress := append(append([]int{}, address...), i)
+		childCalls, childAddresses := flattenCalls(child, childAddress)
+		calls = append(calls, childCalls...)
+		addresses = append(addresses, childAddresses...)
+	}
+	return calls, addresses
+}
+
+// checkParityTraces compares the traces of a transaction with the expected call tree
+func checkParityTraces(traces []TraceBlock, hash string, want expectedCall) error {
+	calls, addresses := flattenCalls(want, []int{})
+	if len(traces) != len(calls) {
+		return fmt.Errorf("got %d traces, expected %d", len(traces), len(calls))
+	}
+	for i, trace := range traces {
+		call := calls[i]
+		if common.HexToHash(trace.TransactionHash) != common.HexToHash(hash) {
+			return fmt.Errorf("trace %d belongs to %s", i, trace.TransactionHash)
+		}
+		if fmt.Sprint(trace.TraceAddress) != fmt.Sprint(addresses[i]) {
+			return fmt.Errorf("trace %d has traceAddress %v, expected %v", i, trace.TraceAddress, addresses[i])
+		}
+		if !strings.EqualFold(trace.Type, call.typ) {
+			return fmt.Errorf("trace %v is a %s, expected %s", addresses[i], trace.Type, call.typ)
+		}
+		if trace.Subtraces != len(call.calls) {
+			return fmt.Errorf("trace %v has %d subtraces, expected %d", addresses[i], trace.Subtraces, len(call.calls))
+		}
+		if reverted := trace.Error != ""; reverted != call.reverted {
+			return fmt.Errorf("trace %v reverted is %t, expected %t: %s", addresses[i], reverted, call.reverted, trace.Error)
+		}
+		if call.reverted {
+			continue
+		}
+		if call.output != "" && !strings.EqualFold(trace.Result.Output, call.output) {
+			return fmt.Errorf("trace %v returned %s, expected %s", addresses[i], trace.Result.Output, call.output)
+		}
+		if call.typ == "CREATE" && !common.IsHexAddress(trace.Result.Address) {
+			return fmt.Errorf("create trace %v has no created address", addresses[i])
+		}
+		if len(addresses[i]) > 0 && hexToBig(trace.Result.GasUsed).Cmp(hexToBig(trace.Action.Gas)) > 0 {
+			return fmt.Errorf("trace %v used %s gas, more than the %s it was given", addresses[i], trace.Result.GasUsed, trace.Action.Gas)
+		}
+	}
+	return nil
+}
+
+// compareBlockTraces checks that trace_block returns the same traces for the transaction as trace_transaction
+func compareBlockTraces(blockNumber int64, hash string) error {
+	blockTraces, err := TraceBlockByNumber(blockNumber)
+	if err != nil {
+		return err
+	}
+	var fromBlock []TraceBlock
+	for _, trace := range blockTraces {
+		if common.HexToHash(trace.TransactionHash) == common.HexToHash(hash) {
+			fromBlock = append(fromBlock, trace)
+		}
+	}
+	fromTransaction, err := TraceTransaction(hash)
+	if err != nil {
+		return err
+	}
+	blockJSON, _ := json.Marshal(fromBlock)
+	transactionJSON, _ := json.Marshal(fromTransaction)
+	if string(blockJSON) != string(transactionJSON) {
+		return fmt.Errorf("trace_block returned %s, trace_transaction returned %s", blockJSON, transactionJSON)
+	}
+	return nil
+}
+
+// checkCreatedAddress checks that the address returned by the contract is the one the CREATE frame deployed to
+func checkCreatedAddress(output string, created string) error {
+	returned := common.BytesToAddress(common.FromHex(output))
+	if returned != common.HexToAddress(created) {
+		return fmt.Errorf("contract returned %s, but CREATE deployed to %s", returned.Hex(), created)
+	}
+	return nil
+}
+
+// hexToBig parses a hex quantity, invalid quantities are returned as 0
+func hexToBig(quantity string) *big.Int {
+	value, err := hexutil.DecodeBig(quantity)
+	if err != nil {
+		return big.NewInt(0)
+	}
+	return value
+}
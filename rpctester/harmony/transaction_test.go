@@ -7,6 +7,7 @@ import (
 	"math/big"
 	"percybolmer/rpc-shard-testing/rpctester/methods"
 	"testing"
+	"time"
 )
 
 func (ts *testSuite) test_V1_getStakingTransactionByBlockHashAndIndex(t *testing.T) {
@@ -842,7 +843,7 @@ func test_V1_getPendingCXReceipts(t *testing.T) {
 			}
 			// This step validates that the returned response is the correct data type
 			if resp.Result != nil {
-				var s []Reciept // Wrong Data type, but func does not work
+				var s []PendingCXReceipt
 				err = json.Unmarshal(resp.Result, &s)
 				if err != nil {
 					testMetrics = append(testMetrics, TestMetric{
@@ -932,7 +933,7 @@ func test_V2_getPendingCXReceipts(t *testing.T) {
 			}
 			// This step validates that the returned response is the correct data type
 			if resp.Result != nil {
-				var s []Reciept // Wrong Data type, but func does not work
+				var s []PendingCXReceipt
 				err = json.Unmarshal(resp.Result, &s)
 				if err != nil {
 					testMetrics = append(testMetrics, TestMetric{
@@ -960,7 +961,10 @@ func test_V2_getPendingCXReceipts(t *testing.T) {
 	}
 }
 
-func test_V1_getCXReceiptByHash(t *testing.T) {
+func (ts *testSuite) test_V1_getCXReceiptByHash(t *testing.T) {
+	if ts.LastCrossShardTransactionHash == "" {
+		t.Skip("cannot fetch CX receipt without a cross shard transaction")
+	}
 	type testcase struct {
 		name              string
 		br                BaseRequest
@@ -975,7 +979,7 @@ func test_V1_getCXReceiptByHash(t *testing.T) {
 				JsonRPC: "2.0",
 				Method:  methods.METHOD_transaction_V1_getCXReceiptByHash,
 				Params: []interface{}{
-					"0x6b106dc5619c86b6c0cb64b17e5c464e8008e08cf0f1bb0e3fa2657fb42daade",
+					ts.LastCrossShardTransactionHash,
 				},
 			},
 		},
@@ -1025,7 +1029,7 @@ func test_V1_getCXReceiptByHash(t *testing.T) {
 			}
 			// This step validates that the returned response is the correct data type
 			if resp.Result != nil {
-				var s []Reciept
+				var s RecieptV1
 				err = json.Unmarshal(resp.Result, &s)
 				if err != nil {
 					testMetrics = append(testMetrics, TestMetric{
@@ -1039,6 +1043,19 @@ func test_V1_getCXReceiptByHash(t *testing.T) {
 					t.Error(err)
 					return
 				}
+				// Verify that the receipt belongs to the cross shard transaction
+				if s.Hash != ts.LastCrossShardTransactionHash || s.ToShardID != int(ts.LastCrossShardToShardID) {
+					testMetrics = append(testMetrics, TestMetric{
+						Method:   tc.br.Method,
+						Test:     tc.name,
+						Pass:     false,
+						Duration: resp.Duration,
+						Error:    "cx receipt does not match the cross shard transaction",
+						Params:   tc.br.Params,
+					})
+					t.Error("cx receipt does not match the cross shard transaction")
+					return
+				}
 			}
 
 			testMetrics = append(testMetrics, TestMetric{
@@ -1052,7 +1069,10 @@ func test_V1_getCXReceiptByHash(t *testing.T) {
 		})
 	}
 }
-func test_V2_getCXReceiptByHash(t *testing.T) {
+func (ts *testSuite) test_V2_getCXReceiptByHash(t *testing.T) {
+	if ts.LastCrossShardTransactionHash == "" {
+		t.Skip("cannot fetch CX receipt without a cross shard transaction")
+	}
 	type testcase struct {
 		name              string
 		br                BaseRequest
@@ -1067,7 +1087,7 @@ func test_V2_getCXReceiptByHash(t *testing.T) {
 				JsonRPC: "2.0",
 				Method:  methods.METHOD_transaction_V2_getCXReceiptByHash,
 				Params: []interface{}{
-					"0x6b106dc5619c86b6c0cb64b17e5c464e8008e08cf0f1bb0e3fa2657fb42daade",
+					ts.LastCrossShardTransactionHash,
 				},
 			},
 		},
@@ -1117,7 +1137,7 @@ func test_V2_getCXReceiptByHash(t *testing.T) {
 			}
 			// This step validates that the returned response is the correct data type
 			if resp.Result != nil {
-				var s []Reciept // Wrong Data type, but func does not work
+				var s Reciept
 				err = json.Unmarshal(resp.Result, &s)
 				if err != nil {
 					testMetrics = append(testMetrics, TestMetric{
@@ -1131,6 +1151,19 @@ func test_V2_getCXReceiptByHash(t *testing.T) {
 					t.Error(err)
 					return
 				}
+				// Verify that the receipt belongs to the cross shard transaction
+				if s.Hash != ts.LastCrossShardTransactionHash || s.ToShardID.Int64() != int64(ts.LastCrossShardToShardID) {
+					testMetrics = append(testMetrics, TestMetric{
+						Method:   tc.br.Method,
+						Test:     tc.name,
+						Pass:     false,
+						Duration: resp.Duration,
+						Error:    "cx receipt does not match the cross shard transaction",
+						Params:   tc.br.Params,
+					})
+					t.Error("cx receipt does not match the cross shard transaction")
+					return
+				}
 			}
 
 			testMetrics = append(testMetrics, TestMetric{
@@ -1376,6 +1409,7 @@ func (ts *testSuite) test_sendRawStakingTransaction(t *testing.T) {
 			tc.br.Params = append(tc.br.Params, payload)
 
 			var result string
+			submitted := time.Now()
 
 			resp, err := callAndValidateDataType(t, tc.name, tc.expectedErrorCode, tc.br, &result)
 			if err != nil {
@@ -1389,6 +1423,9 @@ func (ts *testSuite) test_sendRawStakingTransaction(t *testing.T) {
 				t.Error(err)
 				return
 			}
+			// Store the hash so that waitForReceipt and the getStakingTransactionBy tests can use it
+			ts.LastStakingTransactionHash = result
+			TrackSubmission(result, submitted)
 
 			testMetrics = append(testMetrics, TestMetric{
 				Method:   tc.br.Method,
@@ -0,0 +1,146 @@
+package harmony
+
+import (
+	"fmt"
+	"math/big"
+	"percybolmer/rpc-shard-testing/rpctester/crypto"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+const METRIC_txPoolAdmission = "tx_pool_admission"
+
+// Rejections returned by hmy_sendRawTransaction, the error sink holds the same message with details prepended
+const (
+	RejectNonceTooLow        = "nonce too low"
+	RejectInsufficientFunds  = "insufficient funds for gas * price + value"
+	RejectIntrinsicGas       = "intrinsic gas too low"
+	RejectInvalidChainID     = "invalid chain id for signer"
+	RejectOversizedData      = "oversized data"
+	RejectReplaceUnderpriced = "replacement transaction underpriced"
+)
+
+// OversizedDataSize is larger than the 128KB the pool accepts but smaller than the 138KB the RPC accepts encoded,
+// so the transaction reaches the pool and is reported in the error sink
+const OversizedDataSize = 129 * 1024
+
+// PoolTransaction is a plain transaction that is signed exactly as given
+// Unlike createSignedRLPString nothing is estimated or suggested, so that invalid transactions can be built
+type PoolTransaction struct {
+	Signer   crypto.Signer
+	Nonce    uint64
+	To       common.Address
+	Amount   *big.Int
+	GasLimit uint64
+	GasPrice *big.Int
+	Data     []byte
+	ChainID  *big.Int
+}
+
+// Sign returns the RLP and hash of the signed transaction, it is sent to the shard it originates from
+func (pt PoolTransaction) Sign() (string, common.Hash, error) {
+	shardID, err := crypto.GetShardID()
+	if err != nil {
+		return "", common.Hash{}, err
+	}
+	tx := types.NewCrossShardTransaction(pt.Nonce, &pt.To, uint32(shardID), uint32(shardID), pt.Amount, pt.GasLimit, pt.GasPrice, pt.Data)
+	return signTransaction(pt.Signer, tx, pt.ChainID)
+}
+
+// TransactionErrorSink returns the rejected transactions reported by method, hmy_ or hmyv2_getCurrentTransactionErrorSink
+func TransactionErrorSink(method string) ([]ErrorSinkLog, error) {
+	var result []ErrorSinkLog
+	_, err := CallMethod(URL, method, nil, &result)
+	return result, err
+}
+
+// FindErrorSink returns the error sink entry of hash, or nil if the sink does not hold it
+func FindErrorSink(method string, hash common.Hash) (*ErrorSinkLog, error) {
+	sink, err := TransactionErrorSink(method)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sink {
+		if common.HexToHash(sink[i].TxHashID) == hash {
+			return &sink[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// PendingTransactionHashes returns the hashes in the pool reported by method, hmy_ or hmyv2_pendingTransactions
+// The pool holds both executable and queued transactions
+func PendingTransactionHashes(method string) (map[common.Hash]bool, error) {
+	var result []struct {
+		Hash string `json:"hash"`
+	}
+	if _, err := CallMethod(URL, method, nil, &result); err != nil {
+		return nil, err
+	}
+	hashes := make(map[common.Hash]bool, len(result))
+	for _, tx := range result {
+		hashes[common.HexToHash(tx.Hash)] = true
+	}
+	return hashes, nil
+}
+
+// CheckRejected verifies that sending hash failed with rejection and that the transaction is not in the pool
+// If inSink is true the error sink has to report the rejection, otherwise the RPC rejected the transaction before
+// it reached the pool and the sink may not hold it
+func CheckRejected(hash common.Hash, sendErr error, rejection string, inSink bool) error {
+	if sendErr == nil {
+		return fmt.Errorf("transaction %s was accepted, expected %q", hash.Hex(), rejection)
+	}
+	if !strings.Contains(sendErr.Error(), rejection) {
+		return fmt.Errorf("transaction %s was rejected with %q, expected %q", hash.Hex(), sendErr.Error(), rejection)
+	}
+	for _, method := range []string{methods.METHOD_transaction_V1_getCurrentTransactionErrorSink, methods.METHOD_transaction_V2_getCurrentTransactionErrorSink} {
+		entry, err := FindErrorSink(method, hash)
+		if err != nil {
+			return err
+		}
+		switch {
+		case inSink && entry == nil:
+			return fmt.Errorf("%s does not hold the rejected transaction %s", method, hash.Hex())
+		case inSink && !strings.Contains(entry.ErrorMessage, rejection):
+			return fmt.Errorf("%s reports %q for %s, expected %q", method, entry.ErrorMessage, hash.Hex(), rejection)
+		case !inSink && entry != nil:
+			return fmt.Errorf("%s holds %s with %q, but the RPC should reject it before the pool", method, hash.Hex(), entry.ErrorMessage)
+		}
+	}
+	return checkInPool(hash, false)
+}
+
+// CheckPooled verifies that hash is in the pool and not reported by the error sink
+func CheckPooled(hash common.Hash) error {
+	for _, method := range []string{methods.METHOD_transaction_V1_getCurrentTransactionErrorSink, methods.METHOD_transaction_V2_getCurrentTransactionErrorSink} {
+		entry, err := FindErrorSink(method, hash)
+		if err != nil {
+			return err
+		}
+		if entry != nil {
+			return fmt.Errorf("%s holds the pooled transaction %s with %q", method, hash.Hex(), entry.ErrorMessage)
+		}
+	}
+	return checkInPool(hash, true)
+}
+
+// checkInPool verifies that both versions of pendingTransactions agree with want
+func checkInPool(hash common.Hash, want bool) error {
+	for _, method := range []string{methods.METHOD_transaction_V1_pendingTransactions, methods.METHOD_transaction_V2_pendingTransactions} {
+		pending, err := PendingTransactionHashes(method)
+		if err != nil {
+			return err
+		}
+		if pending[hash] != want {
+			if want {
+				return fmt.Errorf("%s does not hold the pooled transaction %s", method, hash.Hex())
+			}
+			return fmt.Errorf("%s holds the rejected transaction %s", method, hash.Hex())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package harmony
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"percybolmer/rpc-shard-testing/rpctester/crypto"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// test_txPoolAdmission submits transactions that break the pool admission rules, each has to be rejected with the
+// expected error, be reported by the error sink unless the RPC rejects it first, and stay out of pendingTransactions
+func test_txPoolAdmission(t *testing.T) {
+	from := common.HexToAddress(TestAddress)
+	signer := crypto.GetSigner()
+	nonces := GetNonceManager(from)
+	chainID, err := ethClient.ChainID(context.Background())
+	if err != nil {
+		failMetric(t, METRIC_txPoolAdmission, err)
+		return
+	}
+	price, err := GasPrice()
+	if err != nil {
+		failMetric(t, METRIC_txPoolAdmission, err)
+		return
+	}
+	confirmed, err := ethClient.NonceAt(context.Background(), from, nil)
+	if err != nil {
+		failMetric(t, METRIC_txPoolAdmission, err)
+		return
+	}
+	key, err := ethcrypto.GenerateKey()
+	if err != nil {
+		failMetric(t, METRIC_txPoolAdmission, err)
+		return
+	}
+	broke := crypto.NewKeySigner(key)
+	// Every case that needs a valid nonce shares one, none of them should use it up
+	nonce, err := nonces.Next()
+	if err != nil {
+		failMetric(t, METRIC_txPoolAdmission, err)
+		return
+	}
+	defer nonces.Resync()
+
+	type testcase struct {
+		name      string
+		tx        PoolTransaction
+		rejection string
+		inSink    bool
+	}
+	transfer := func(signer crypto.Signer, nonce uint64) PoolTransaction {
+		return PoolTransaction{Signer: signer, Nonce: nonce, To: signer.Address(), Amount: big.NewInt(0), GasLimit: transferGas, GasPrice: price, ChainID: chainID}
+	}
+
+	modified := func(tx PoolTransaction, modify func(tx *PoolTransaction)) PoolTransaction {
+		modify(&tx)
+		return tx
+	}
+
+	testCases := []testcase{
+		{
+			name:      "insufficient_funds",
+			tx:        modified(transfer(broke, 0), func(tx *PoolTransaction) { tx.Amount = ONE }),
+			rejection: RejectInsufficientFunds,
+			inSink:    true,
+		},
+		{
+			name:      "intrinsic_gas_too_low",
+			tx:        modified(transfer(signer, nonce), func(tx *PoolTransaction) { tx.GasLimit = transferGas - 1 }),
+			rejection: RejectIntrinsicGas,
+			inSink:    true,
+		},
+		{
+			// The chain ID is verified by the RPC before the transaction reaches the pool
+			name:      "wrong_chain_id",
+			tx:        modified(transfer(signer, nonce), func(tx *PoolTransaction) { tx.ChainID = new(big.Int).Add(chainID, big.NewInt(1)) }),
+			rejection: RejectInvalidChainID,
+			inSink:    false,
+		},
+		{
+			name: "oversized_data",
+			tx: modified(transfer(signer, nonce), func(tx *PoolTransaction) {
+				tx.Data = make([]byte, OversizedDataSize)
+				tx.GasLimit = auth.GasLimit
+			}),
+			rejection: RejectOversizedData,
+			inSink:    true,
+		},
+	}
+	// The account has to have sent a transaction for any nonce to be too low
+	if confirmed > 0 {
+		testCases = append(testCases, testcase{name: "nonce_too_low", tx: transfer(signer, confirmed-1), rejection: RejectNonceTooLow, inSink: true})
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start := time.Now()
+			rlp, hash, err := tc.tx.Sign()
+			if err != nil {
+				failMetric(t, METRIC_txPoolAdmission, err)
+				return
+			}
+			_, sendErr := SendRawTransaction(rlp)
+			if err := CheckRejected(hash, sendErr, tc.rejection, tc.inSink); err != nil {
+				failMetric(t, METRIC_txPoolAdmission, err)
+				return
+			}
+			passMetric(t, METRIC_txPoolAdmission, start)
+		})
+	}
+
+	t.Run("replacement_underpriced", func(t *testing.T) {
+		start := time.Now()
+		if err := testReplacementUnderpriced(signer, nonces, price, chainID); err != nil {
+			failMetric(t, METRIC_txPoolAdmission, err)
+			return
+		}
+		passMetric(t, METRIC_txPoolAdmission, start)
+	})
+}
+
+// testReplacementUnderpriced queues a transaction behind a nonce gap so that it stays in the pool, replaces it at a lower
+// price and then fills the gap. The replacement has to be rejected and the original transaction mined
+func testReplacementUnderpriced(signer crypto.Signer, nonces *NonceManager, price *big.Int, chainID *big.Int) error {
+	if err := nonces.Resync(); err != nil {
+		return err
+	}
+	gap, err := nonces.Next()
+	if err != nil {
+		return err
+	}
+	queuedNonce, err := nonces.Next()
+	if err != nil {
+		return err
+	}
+	queued := PoolTransaction{Signer: signer, Nonce: queuedNonce, To: signer.Address(), Amount: big.NewInt(0), GasLimit: transferGas, GasPrice: new(big.Int).Mul(price, big.NewInt(2)), ChainID: chainID}
+	rlp, queuedHash, err := queued.Sign()
+	if err != nil {
+		return err
+	}
+	if _, err := SendRawTransaction(rlp); err != nil {
+		return fmt.Errorf("queueing transaction with nonce %d: %w", queuedNonce, err)
+	}
+
+	// Whatever the checks find, the gap is filled so that the account is not stuck
+	checkErr := CheckPooled(queuedHash)
+	if checkErr == nil {
+		replacement := queued
+		replacement.GasPrice = price
+		rlp, replacementHash, err := replacement.Sign()
+		if err != nil {
+			return err
+		}
+		_, sendErr := SendRawTransaction(rlp)
+		checkErr = CheckRejected(replacementHash, sendErr, RejectReplaceUnderpriced, true)
+	}
+
+	filler := PoolTransaction{Signer: signer, Nonce: gap, To: signer.Address(), Amount: big.NewInt(0), GasLimit: transferGas, GasPrice: price, ChainID: chainID}
+	rlp, _, err = filler.Sign()
+	if err != nil {
+		return err
+	}
+	if _, err := SendRawTransaction(rlp); err != nil {
+		return fmt.Errorf("filling the nonce gap at %d: %w", gap, err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+	receipt, err := WaitForReceipt(ctx, queuedHash.Hex())
+	if err != nil {
+		return fmt.Errorf("the queued transaction %s was not mined after the gap was filled: %w", queuedHash.Hex(), err)
+	}
+	if receipt.Status != 1 {
+		return fmt.Errorf("the queued transaction %s failed with status %d", queuedHash.Hex(), receipt.Status)
+	}
+	return checkErr
+}
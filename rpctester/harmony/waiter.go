@@ -0,0 +1,165 @@
+package harmony
+
+import (
+	"context"
+	"percybolmer/rpc-shard-testing/rpctester/methods"
+	"sync"
+	"time"
+)
+
+const (
+	// METRIC_submitToInclusion is the name used when reporting the time between sending a transaction and it being included
+	METRIC_submitToInclusion = "submit_to_inclusion"
+
+	// minBackoff is the first delay between two polls, it doubles until maxBackoff is reached
+	minBackoff = 250 * time.Millisecond
+	maxBackoff = 4 * time.Second
+
+	// trackingTTL is how long submissions and inclusions are kept, most transactions are never waited for or read
+	trackingTTL = 10 * time.Minute
+)
+
+var (
+	// Confirmations is the amount of blocks that has to be built on top of a transaction before
+	// WaitForReceipt considers it done, configured with CONFIRMATIONS in the .env file
+	Confirmations int64
+
+	submissionsLock sync.Mutex
+	submissions     = map[string]time.Time{}
+	inclusions      = map[string]inclusion{}
+	lastPrune       time.Time
+)
+
+// inclusion is the latency measured by WaitForReceipt and when it was measured
+type inclusion struct {
+	latency  time.Duration
+	measured time.Time
+}
+
+// SendRawTransaction submits a signed transaction and remembers the time it was sent
+func SendRawTransaction(rlp string) (string, error) {
+	submitted := time.Now()
+	var hash string
+	if _, err := CallMethod(URL, methods.METHOD_transaction_sendRawTransaction, []interface{}{rlp}, &hash); err != nil {
+		return "", err
+	}
+	TrackSubmission(hash, submitted)
+	return hash, nil
+}
+
+// TrackSubmission is used for transactions that are not sent by SendRawTransaction
+// so that their inclusion latency can be measured
+func TrackSubmission(hash string, submitted time.Time) {
+	submissionsLock.Lock()
+	defer submissionsLock.Unlock()
+	pruneTracking(time.Now())
+	submissions[hash] = submitted
+}
+
+// InclusionLatency returns the time between submitting the transaction and WaitForReceipt finding it
+// false is returned if the submission was never tracked, the transaction has not been waited for
+// or the latency was already read, it is forgotten once returned
+func InclusionLatency(hash string) (time.Duration, bool) {
+	submissionsLock.Lock()
+	defer submissionsLock.Unlock()
+	included, ok := inclusions[hash]
+	delete(inclusions, hash)
+	return included.latency, ok
+}
+
+// pruneTracking drops submissions and inclusions older than trackingTTL, it runs at most once every trackingTTL
+// submissionsLock has to be held
+func pruneTracking(now time.Time) {
+	if now.Sub(lastPrune) < trackingTTL {
+		return
+	}
+	lastPrune = now
+	for hash, submitted := range submissions {
+		if now.Sub(submitted) > trackingTTL {
+			delete(submissions, hash)
+		}
+	}
+	for hash, included := range inclusions {
+		if now.Sub(included.measured) > trackingTTL {
+			delete(inclusions, hash)
+		}
+	}
+}
+
+// WaitForReceipt polls getTransactionReceipt with backoff until the transaction is included
+// and Confirmations blocks has been built on top of it
+func WaitForReceipt(ctx context.Context, hash string) (*TransactionReceipt_V2, error) {
+	var receipt TransactionReceipt_V2
+	err := pollWithBackoff(ctx, minBackoff, maxBackoff, func() (bool, error) {
+		// Unmarshalling null does not reset the receipt, so use a new one every poll
+		var r TransactionReceipt_V2
+		if _, err := CallMethod(URL, methods.METHOD_transaction_V2_getTransactionReceipt, []interface{}{hash}, &r); err != nil {
+			return false, err
+		}
+		receipt = r
+		return receipt.BlockHash != "", nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	submissionsLock.Lock()
+	if submitted, ok := submissions[hash]; ok {
+		now := time.Now()
+		pruneTracking(now)
+		inclusions[hash] = inclusion{latency: now.Sub(submitted), measured: now}
+		delete(submissions, hash)
+	}
+	submissionsLock.Unlock()
+
+	if Confirmations > 0 {
+		if err := WaitForBlock(ctx, receipt.BlockNumber+Confirmations); err != nil {
+			return &receipt, err
+		}
+	}
+	return &receipt, nil
+}
+
+// WaitForBlock polls the block number with backoff until the chain has reached block n
+func WaitForBlock(ctx context.Context, n int64) error {
+	return pollWithBackoff(ctx, minBackoff, maxBackoff, func() (bool, error) {
+		current, err := BlockNumber()
+		if err != nil {
+			return false, err
+		}
+		return current >= n, nil
+	})
+}
+
+// BlockNumber returns the latest block number
+func BlockNumber() (int64, error) {
+	var current int64
+	if _, err := CallMethod(URL, methods.METHOD_protocol_V2_blockNumber, nil, &current); err != nil {
+		return 0, err
+	}
+	return current, nil
+}
+
+// pollWithBackoff calls fn until it reports done, returns an error or ctx is cancelled
+// The delay between calls starts at min and is doubled after every call until it reaches max
+func pollWithBackoff(ctx context.Context, min, max time.Duration, fn func() (bool, error)) error {
+	delay := min
+	for {
+		done, err := fn()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if delay > max {
+			delay = max
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package harmony
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// test_waitForReceipt waits for the transactions sent by the suite to be included
+// so that the tests after it can use their receipts and blocks
+func (ts *testSuite) test_waitForReceipt(t *testing.T) {
+	type testcase struct {
+		name    string
+		hash    string
+		timeout time.Duration
+		staking bool
+	}
+
+	testCases := []testcase{}
+	if ts.LastTransactionHash != "" {
+		testCases = append(testCases, testcase{
+			name:    fmt.Sprintf("%s_last_transaction", t.Name()),
+			hash:    ts.LastTransactionHash,
+			timeout: 1 * time.Minute,
+		})
+	}
+	// The getStakingTransactionBy tests need the staking transaction to be in a block
+	if ts.LastStakingTransactionHash != "" {
+		testCases = append(testCases, testcase{
+			name:    fmt.Sprintf("%s_last_staking_transaction", t.Name()),
+			hash:    ts.LastStakingTransactionHash,
+			timeout: 1 * time.Minute,
+			staking: true,
+		})
+	}
+	if len(testCases) == 0 {
+		t.Skip("no transaction has been sent to wait for")
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+
+			receipt, err := WaitForReceipt(ctx, tc.hash)
+			if err != nil {
+				testMetrics = append(testMetrics, TestMetric{
+					Method: METRIC_submitToInclusion,
+					Test:   tc.name,
+					Pass:   false,
+					Error:  err.Error(),
+					Params: []interface{}{tc.hash},
+				})
+				t.Error(err)
+				return
+			}
+			if tc.staking {
+				ts.LastStakingTransactionBlockHash = receipt.BlockHash
+			}
+			latency, _ := InclusionLatency(tc.hash)
+			t.Logf("transaction included in block %d after %s", receipt.BlockNumber, latency)
+
+			testMetrics = append(testMetrics, TestMetric{
+				Method:   METRIC_submitToInclusion,
+				Test:     tc.name,
+				Pass:     true,
+				Duration: latency.String(),
+				Params:   []interface{}{tc.hash},
+			})
+		})
+	}
+}
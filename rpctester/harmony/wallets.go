@@ -0,0 +1,46 @@
+package harmony
+
+import (
+	"errors"
+	"percybolmer/rpc-shard-testing/rpctester/crypto"
+	"sync/atomic"
+)
+
+// WalletPool holds accounts derived from the MNEMONIC and hands them out round robin
+// Spreading transactions across accounts avoids waiting on the nonce of a single account
+type WalletPool struct {
+	wallets []crypto.Signer
+	next    uint32
+}
+
+// NewWalletPool derives n wallets from the MNEMONIC in the .env file
+func NewWalletPool(n int) (*WalletPool, error) {
+	if n <= 0 {
+		return nil, errors.New("wallet pool needs at least one wallet")
+	}
+	keys, err := crypto.DeriveAccounts(crypto.GetMnemonic(), n)
+	if err != nil {
+		return nil, err
+	}
+	pool := &WalletPool{}
+	for _, key := range keys {
+		pool.wallets = append(pool.wallets, crypto.NewKeySigner(key))
+	}
+	return pool, nil
+}
+
+// NewWalletPoolFromWallets creates a pool of already known wallets
+func NewWalletPoolFromWallets(wallets ...crypto.Signer) *WalletPool {
+	return &WalletPool{wallets: wallets}
+}
+
+// Next returns the next wallet in the pool, it is safe to call concurrently
+func (wp *WalletPool) Next() crypto.Signer {
+	index := atomic.AddUint32(&wp.next, 1) - 1
+	return wp.wallets[int(index)%len(wp.wallets)]
+}
+
+// Wallets returns all wallets in the pool
+func (wp *WalletPool) Wallets() []crypto.Signer {
+	return wp.wallets
+}
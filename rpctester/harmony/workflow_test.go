@@ -0,0 +1,302 @@
+package harmony
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"percybolmer/rpc-shard-testing/rpctester/contracts/devtoken"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// workflowRecipient is an account without keys that receives tokens during the workflow
+var workflowRecipient = common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+
+// expectedEvent is a Devtoken event that a workflow step should emit
+// from and to are the indexed addresses, for Staked only from is used
+type expectedEvent struct {
+	name  string
+	from  common.Address
+	to    common.Address
+	value *big.Int
+}
+
+// test_devtokenWorkflow runs every state changing Devtoken function through the RPC
+// After each step the balances are checked with hmy_call and the events in the receipt
+// are decoded and compared with hmy_getLogs
+func (ts *testSuite) test_devtokenWorkflow(t *testing.T) {
+	owner := common.HexToAddress(TestAddress)
+	zero := common.Address{}
+	amount := big.NewInt(0).Mul(big.NewInt(10), ONE)
+	half := big.NewInt(0).Div(amount, big.NewInt(2))
+
+	type testcase struct {
+		name   string
+		method string
+		// args is a function so that steps can depend on the state left by earlier steps
+		args   func() ([]interface{}, error)
+		deltas map[common.Address]*big.Int
+		events []expectedEvent
+	}
+
+	testCases := []testcase{
+		{
+			name:   fmt.Sprintf("%s_transfer", t.Name()),
+			method: "transfer",
+			args:   staticArgs(workflowRecipient, amount),
+			deltas: map[common.Address]*big.Int{owner: neg(amount), workflowRecipient: amount},
+			events: []expectedEvent{{name: "Transfer", from: owner, to: workflowRecipient, value: amount}},
+		},
+		{
+			name:   fmt.Sprintf("%s_approve", t.Name()),
+			method: "approve",
+			args:   staticArgs(owner, half),
+			deltas: map[common.Address]*big.Int{owner: big.NewInt(0)},
+			events: []expectedEvent{{name: "Approval", from: owner, to: owner, value: half}},
+		},
+		{
+			name:   fmt.Sprintf("%s_transferFrom", t.Name()),
+			method: "transferFrom",
+			args:   staticArgs(owner, workflowRecipient, half),
+			deltas: map[common.Address]*big.Int{owner: neg(half), workflowRecipient: half},
+			events: []expectedEvent{
+				{name: "Transfer", from: owner, to: workflowRecipient, value: half},
+				{name: "Approval", from: owner, to: owner, value: big.NewInt(0)},
+			},
+		},
+		{
+			name:   fmt.Sprintf("%s_mint", t.Name()),
+			method: "mint",
+			args:   staticArgs(workflowRecipient, amount),
+			deltas: map[common.Address]*big.Int{workflowRecipient: amount},
+			events: []expectedEvent{{name: "Transfer", from: zero, to: workflowRecipient, value: amount}},
+		},
+		{
+			name:   fmt.Sprintf("%s_burn", t.Name()),
+			method: "burn",
+			args:   staticArgs(workflowRecipient, amount),
+			deltas: map[common.Address]*big.Int{workflowRecipient: neg(amount)},
+			events: []expectedEvent{{name: "Transfer", from: workflowRecipient, to: zero, value: amount}},
+		},
+		{
+			name:   fmt.Sprintf("%s_stake", t.Name()),
+			method: "stake",
+			args:   staticArgs(amount),
+			deltas: map[common.Address]*big.Int{owner: neg(amount)},
+			events: []expectedEvent{
+				{name: "Staked", from: owner, value: amount},
+				{name: "Transfer", from: owner, to: zero, value: amount},
+			},
+		},
+		{
+			name:   fmt.Sprintf("%s_withdrawStake", t.Name()),
+			method: "withdrawStake",
+			args: func() ([]interface{}, error) {
+				index, err := lastStakeIndex(owner, amount)
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{amount, index}, nil
+			},
+			// The reward is 0 since the stake was made less than an hour ago
+			deltas: map[common.Address]*big.Int{owner: amount},
+			events: []expectedEvent{{name: "Transfer", from: zero, to: owner, value: amount}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start := time.Now()
+			fail := func(err error) {
+				testMetrics = append(testMetrics, TestMetric{
+					Method: METRIC_devtokenWorkflow,
+					Test:   tc.name,
+					Pass:   false,
+					Error:  err.Error(),
+				})
+				t.Error(err)
+			}
+
+			args, err := tc.args()
+			if err != nil {
+				fail(err)
+				return
+			}
+			before, err := tokenBalances(tc.deltas)
+			if err != nil {
+				fail(err)
+				return
+			}
+
+			hash, err := SendDevtokenTransaction(tc.method, args...)
+			if err != nil {
+				fail(err)
+				return
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+			defer cancel()
+			receipt, err := WaitForReceipt(ctx, hash)
+			if err != nil {
+				fail(err)
+				return
+			}
+			if receipt.Status != 1 {
+				fail(fmt.Errorf("%s failed with status %d", hash, receipt.Status))
+				return
+			}
+
+			after, err := tokenBalances(tc.deltas)
+			if err != nil {
+				fail(err)
+				return
+			}
+			for account, delta := range tc.deltas {
+				if got := big.NewInt(0).Sub(after[account], before[account]); got.Cmp(delta) != 0 {
+					fail(fmt.Errorf("balance of %s changed by %s, expected %s", account.Hex(), got, delta))
+					return
+				}
+			}
+
+			if err := matchEvents(receipt.Logs, tc.events); err != nil {
+				fail(err)
+				return
+			}
+
+			logs, err := GetLogs(LogFilter{
+				FromBlock: hexutil.EncodeUint64(uint64(receipt.BlockNumber)),
+				ToBlock:   hexutil.EncodeUint64(uint64(receipt.BlockNumber)),
+				Address:   SmartContractAddress.Hex(),
+			})
+			if err != nil {
+				fail(err)
+				return
+			}
+			if err := compareLogs(receipt.Logs, logsForTransaction(logs, hash)); err != nil {
+				fail(fmt.Errorf("hmy_getLogs does not match the receipt: %w", err))
+				return
+			}
+
+			testMetrics = append(testMetrics, TestMetric{
+				Method:   METRIC_devtokenWorkflow,
+				Test:     tc.name,
+				Pass:     true,
+				Duration: time.Since(start).String(),
+				Params:   []interface{}{hash},
+			})
+		})
+	}
+}
+
+func staticArgs(args ...interface{}) func() ([]interface{}, error) {
+	return func() ([]interface{}, error) {
+		return args, nil
+	}
+}
+
+func neg(x *big.Int) *big.Int {
+	return big.NewInt(0).Neg(x)
+}
+
+// tokenBalances fetches the Devtoken balance of every account in deltas
+func tokenBalances(deltas map[common.Address]*big.Int) (map[common.Address]*big.Int, error) {
+	balances := make(map[common.Address]*big.Int, len(deltas))
+	for account := range deltas {
+		balance, err := TokenBalanceOf(account)
+		if err != nil {
+			return nil, err
+		}
+		balances[account] = balance
+	}
+	return balances, nil
+}
+
+// lastStakeIndex finds the newest stake of staker that holds amount
+func lastStakeIndex(staker common.Address, amount *big.Int) (*big.Int, error) {
+	summary, err := deployedToken.HasStake(&bind.CallOpts{From: staker}, staker)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(summary.Stakes) - 1; i >= 0; i-- {
+		if summary.Stakes[i].Amount.Cmp(amount) == 0 {
+			return big.NewInt(int64(i)), nil
+		}
+	}
+	return nil, errors.New("no stake found to withdraw")
+}
+
+// matchEvents decodes the logs and compares them in order with the expected events
+func matchEvents(logs []TransactionLog, expected []expectedEvent) error {
+	if len(logs) != len(expected) {
+		return fmt.Errorf("receipt has %d logs, expected %d", len(logs), len(expected))
+	}
+	for i, log := range logs {
+		event, err := DecodeDevtokenEvent(log)
+		if err != nil {
+			return err
+		}
+		want := expected[i]
+		var (
+			name     string
+			from, to common.Address
+			value    *big.Int
+		)
+		switch e := event.(type) {
+		case *devtoken.DevtokenTransfer:
+			name, from, to, value = "Transfer", e.From, e.To, e.Value
+		case *devtoken.DevtokenApproval:
+			name, from, to, value = "Approval", e.Owner, e.Spender, e.Value
+		case *devtoken.DevtokenStaked:
+			name, from, value = "Staked", e.User, e.Amount
+		default:
+			return fmt.Errorf("log %d is an unexpected %T", i, event)
+		}
+		if name != want.name || from != want.from || to != want.to || value.Cmp(want.value) != 0 {
+			return fmt.Errorf("log %d is %s(%s, %s, %s), expected %s(%s, %s, %s)", i,
+				name, from.Hex(), to.Hex(), value, want.name, want.from.Hex(), want.to.Hex(), want.value)
+		}
+	}
+	return nil
+}
+
+// logsForTransaction returns the logs emitted by the transaction hash
+func logsForTransaction(logs []TransactionLog, hash string) []TransactionLog {
+	var found []TransactionLog
+	for _, log := range logs {
+		if common.HexToHash(log.TransactionHash) == common.HexToHash(hash) {
+			found = append(found, log)
+		}
+	}
+	return found
+}
+
+// compareLogs checks that two sets of logs are the same, in the same order
+func compareLogs(want, got []TransactionLog) error {
+	if len(want) != len(got) {
+		return fmt.Errorf("got %d logs, expected %d", len(got), len(want))
+	}
+	for i := range want {
+		w, err := ToEthLog(want[i])
+		if err != nil {
+			return err
+		}
+		g, err := ToEthLog(got[i])
+		if err != nil {
+			return err
+		}
+		if logKey(w) != logKey(g) {
+			return fmt.Errorf("log %d is %s, expected %s", i, logKey(g), logKey(w))
+		}
+	}
+	return nil
+}
+
+// logKey identifies a log by its position and content
+func logKey(log ethtypes.Log) string {
+	return fmt.Sprintf("%s/%s/%d %s %v %s", log.BlockHash.Hex(), log.TxHash.Hex(), log.Index, log.Address.Hex(), log.Topics, hexutil.Encode(log.Data))
+}
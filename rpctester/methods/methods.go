@@ -22,6 +22,7 @@ const (
 	METHOD_filter_newBlockFilter              = "hmy_newBlockFilter"
 	METHOD_filter_getFilterChanges            = "hmy_getFilterChanges"
 	METHOD_filter_getLogs                     = "hmy_getLogs"
+	METHOD_filter_uninstallFilter             = "hmy_uninstallFilter"
 	/*
 		transactions related methods,
 		Get help with the staking transactions
@@ -49,6 +50,8 @@ const (
 	METHOD_transaction_sendRawTransaction                     = "hmy_sendRawTransaction"
 	METHOD_transaction_V1_getTransactionHistory               = "hmy_getTransactionsHistory"
 	METHOD_transaction_V2_getTransactionHistory               = "hmyv2_getTransactionsHistory"
+	METHOD_transaction_V1_getStakingTransactionHistory        = "hmy_getStakingTransactionsHistory"
+	METHOD_transaction_V2_getStakingTransactionHistory        = "hmyv2_getStakingTransactionsHistory"
 	METHOD_transaction_V1_getTransactionReceipt               = "hmy_getTransactionReceipt"
 	METHOD_transaction_V2_getTransactionReceipt               = "hmyv2_getTransactionReceipt"
 	METHOD_transaction_V1_getBlockTransactionCountByHash      = "hmy_getBlockTransactionCountByHash"
@@ -80,20 +83,22 @@ const (
 	/**
 	Protocol Related
 	*/
-	METHOD_protocol_isLastBlock          = "hmy_isLastBlock"
-	METHOD_protocol_epochLastBlock       = "hmy_epochLastBlock"
-	METHOD_protocol_lastestHeader        = "hmy_latestHeader"
-	METHOD_protocol_getShardingStructure = "hmy_getShardingStructure"
-	METHOD_protocol_V1_blockNumber       = "hmy_blockNumber"
-	METHOD_protocol_V2_blockNumber       = "hmyv2_blockNumber"
-	METHOD_protocol_syncing              = "hmy_syncing"
-	METHOD_protocol_V1_gasPrice          = "hmy_gasPrice"
-	METHOD_protocol_V2_gasPrice          = "hmyv2_gasPrice"
-	METHOD_protocol_peerCount            = "net_peerCount"
-	METHOD_protocol_V1_getEpoch          = "hmy_getEpoch"
-	METHOD_protocol_V2_getEpoch          = "hmyv2_getEpoch"
-	METHOD_protocol_getLeader            = "hmy_getLeader"
-	METHOD_protocol_V2_getSuperCommitees = "hmyv2_getSuperCommitees"
+	METHOD_protocol_isLastBlock           = "hmy_isLastBlock"
+	METHOD_protocol_epochLastBlock        = "hmy_epochLastBlock"
+	METHOD_protocol_lastestHeader         = "hmy_latestHeader"
+	METHOD_protocol_V2_getHeaderByNumber  = "hmyv2_getHeaderByNumber"
+	METHOD_protocol_getShardingStructure  = "hmy_getShardingStructure"
+	METHOD_protocol_V1_blockNumber        = "hmy_blockNumber"
+	METHOD_protocol_V2_blockNumber        = "hmyv2_blockNumber"
+	METHOD_protocol_syncing               = "hmy_syncing"
+	METHOD_protocol_V1_gasPrice           = "hmy_gasPrice"
+	METHOD_protocol_V2_gasPrice           = "hmyv2_gasPrice"
+	METHOD_protocol_peerCount             = "net_peerCount"
+	METHOD_protocol_V1_getEpoch           = "hmy_getEpoch"
+	METHOD_protocol_V2_getEpoch           = "hmyv2_getEpoch"
+	METHOD_protocol_getLeader             = "hmy_getLeader"
+	METHOD_protocol_V1_getSuperCommittees = "hmy_getSuperCommittees"
+	METHOD_protocol_V2_getSuperCommittees = "hmyv2_getSuperCommittees"
 
 	/**
 	Staking Related Methods
@@ -127,8 +132,184 @@ const (
 	METHOD_staking_V1_getElectedValidatorAddresses       = "hmy_getElectedValidatorAddresses"
 	METHOD_staking_V2_getElectedValidatorAddresses       = "hmyv2_getElectedValidatorAddresses"
 	/**
+	Ethereum compatible methods
+	Served for MetaMask and other EVM tooling, addresses and hashes are in the Ethereum format
+	*/
+	METHOD_eth_chainId                             = "eth_chainId"
+	METHOD_eth_protocolVersion                     = "eth_protocolVersion"
+	METHOD_eth_syncing                             = "eth_syncing"
+	METHOD_eth_blockNumber                         = "eth_blockNumber"
+	METHOD_eth_gasPrice                            = "eth_gasPrice"
+	METHOD_eth_getBalance                          = "eth_getBalance"
+	METHOD_eth_getTransactionCount                 = "eth_getTransactionCount"
+	METHOD_eth_getCode                             = "eth_getCode"
+	METHOD_eth_getStorageAt                        = "eth_getStorageAt"
+	METHOD_eth_call                                = "eth_call"
+	METHOD_eth_estimateGas                         = "eth_estimateGas"
+	METHOD_eth_sendRawTransaction                  = "eth_sendRawTransaction"
+	METHOD_eth_getBlockByNumber                    = "eth_getBlockByNumber"
+	METHOD_eth_getBlockByHash                      = "eth_getBlockByHash"
+	METHOD_eth_getBlockTransactionCountByNumber    = "eth_getBlockTransactionCountByNumber"
+	METHOD_eth_getBlockTransactionCountByHash      = "eth_getBlockTransactionCountByHash"
+	METHOD_eth_getTransactionByHash                = "eth_getTransactionByHash"
+	METHOD_eth_getTransactionByBlockNumberAndIndex = "eth_getTransactionByBlockNumberAndIndex"
+	METHOD_eth_getTransactionByBlockHashAndIndex   = "eth_getTransactionByBlockHashAndIndex"
+	METHOD_eth_getTransactionReceipt               = "eth_getTransactionReceipt"
+	METHOD_eth_getLogs                             = "eth_getLogs"
+	METHOD_eth_newFilter                           = "eth_newFilter"
+	METHOD_eth_newBlockFilter                      = "eth_newBlockFilter"
+	METHOD_eth_newPendingTransactionFilter         = "eth_newPendingTransactionFilter"
+	METHOD_eth_getFilterChanges                    = "eth_getFilterChanges"
+	METHOD_eth_getFilterLogs                       = "eth_getFilterLogs"
+	METHOD_eth_uninstallFilter                     = "eth_uninstallFilter"
+	METHOD_net_version                             = "net_version"
+	METHOD_net_listening                           = "net_listening"
+	METHOD_web3_clientVersion                      = "web3_clientVersion"
+	METHOD_web3_sha3                               = "web3_sha3"
+	/**
 	Tracing methods
 	*/
-	METHOD_trace_block       = "trace_block"
-	METHOD_trace_transaction = "trace_transaction"
+	METHOD_trace_block            = "trace_block"
+	METHOD_trace_transaction      = "trace_transaction"
+	METHOD_debug_traceTransaction = "debug_traceTransaction"
 )
+
+// All is every JSON-RPC method in this package
+// METHOD_address is left out since it is an explorer GET endpoint
+// New methods are appended, the fuzz corpus refers to methods by their index
+var All = []string{
+	METHOD_V1_getBalanceByBlockNumber,
+	METHOD_V2_getBalanceByBlockNumber,
+	METHOD_V1_getTransactionCount,
+	METHOD_V2_getTransactionCount,
+	METHOD_V1_getBalance,
+	METHOD_V2_getBalance,
+	METHOD_filter_getFilterLogs,
+	METHOD_filter_newFilter,
+	METHOD_filter_newPendingtransactionFilter,
+	METHOD_filter_newBlockFilter,
+	METHOD_filter_getFilterChanges,
+	METHOD_filter_getLogs,
+	METHOD_filter_uninstallFilter,
+	METHOD_transaction_V1_getStakingTransactionByBlockHashAndIndex,
+	METHOD_transaction_V2_getStakingTransactionByBlockHashAndIndex,
+	METHOD_transaction_V1_getStakingTransactionByBlockNumberAndIndex,
+	METHOD_transaction_V2_getStakingTransactionByBlockNumberAndIndex,
+	METHOD_transaction_V1_getStakingTransactionByHash,
+	METHOD_transaction_V2_getStakingTransactionByHash,
+	METHOD_transaction_V1_getCurrentTransactionErrorSink,
+	METHOD_transaction_V2_getCurrentTransactionErrorSink,
+	METHOD_transaction_V1_getPendingCrossLinks,
+	METHOD_transaction_V2_getPendingCrossLinks,
+	METHOD_transaction_V1_getPendingCXReceipts,
+	METHOD_transaction_V2_getPendingCXReceipts,
+	METHOD_transaction_V1_getCXReceiptByHash,
+	METHOD_transaction_V2_getCXReceiptByHash,
+	METHOD_transaction_V1_pendingTransactions,
+	METHOD_transaction_V2_pendingTransactions,
+	METHOD_transaction_sendRawStakingTransaction,
+	METHOD_transaction_sendRawTransaction,
+	METHOD_transaction_V1_getTransactionHistory,
+	METHOD_transaction_V2_getTransactionHistory,
+	METHOD_transaction_V1_getTransactionReceipt,
+	METHOD_transaction_V2_getTransactionReceipt,
+	METHOD_transaction_V1_getBlockTransactionCountByHash,
+	METHOD_transaction_V2_getBlockTransactionCountByHash,
+	METHOD_transaction_V1_getBlockTransactionCountByNumber,
+	METHOD_transaction_V2_getBlockTransactionCountByNumber,
+	METHOD_transaction_V1_getTransactionByHash,
+	METHOD_transaction_V2_getTransactionByHash,
+	METHOD_transaction_V1_getTransactionByBlockNumberAndIndex,
+	METHOD_transaction_V2_getTransactionByBlockNumberAndIndex,
+	METHOD_transaction_V1_getTransactionByBlockHashAndIndex,
+	METHOD_transaction_V2_getTransactionByBlockHashAndIndex,
+	METHOD_transaction_V1_getBlockByNumber,
+	METHOD_transaction_V2_getBlockByNumber,
+	METHOD_transaction_V1_getBlockByHash,
+	METHOD_transaction_V2_getBlockByHash,
+	METHOD_transaction_V1_getBlocks,
+	METHOD_transaction_V2_getBlocks,
+	METHOD_transaction_tx,
+	METHOD_contract_getStorageAt,
+	METHOD_contract_getCode,
+	METHOD_contract_call,
+	METHOD_contract_estimateGas,
+	METHOD_protocol_isLastBlock,
+	METHOD_protocol_epochLastBlock,
+	METHOD_protocol_lastestHeader,
+	METHOD_protocol_getShardingStructure,
+	METHOD_protocol_V1_blockNumber,
+	METHOD_protocol_V2_blockNumber,
+	METHOD_protocol_syncing,
+	METHOD_protocol_V1_gasPrice,
+	METHOD_protocol_V2_gasPrice,
+	METHOD_protocol_peerCount,
+	METHOD_protocol_V1_getEpoch,
+	METHOD_protocol_V2_getEpoch,
+	METHOD_protocol_getLeader,
+	METHOD_protocol_V2_getSuperCommittees,
+	METHOD_staking_getCirculatingSupply,
+	METHOD_staking_getTotalSupply,
+	METHOD_staking_getStakingNetworkInfo,
+	METHOD_staking_getAllValidatorInformation,
+	METHOD_staking_getAllValidatorInformationByBlockNumber,
+	METHOD_staking_getCurrentUtilityMetrics,
+	METHOD_staking_getDelegationsByValidator,
+	METHOD_staking_getDelegationsByDelegatorAndValidator,
+	METHOD_staking_getDelegationsByDelegator,
+	METHOD_staking_getValidatorMetrics,
+	METHOD_staking_getMedianRawStakeSnapshot,
+	METHOD_staking_getActiveValidatorAddresses,
+	METHOD_staking_V1_getAllValidatorAddresses,
+	METHOD_staking_V2_getAllValidatorAddresses,
+	METHOD_staking_V1_getCurrentStakingErrorSink,
+	METHOD_staking_V2_getCurrentStakingErrorSink,
+	METHOD_staking_getValidatorInformation,
+	METHOD_staking_V1_getValidators,
+	METHOD_staking_V2_getValidators,
+	METHOD_staking_getSignedBlocks,
+	METHOD_staking_V1_isBlockSigner,
+	METHOD_staking_V2_isBlockSigner,
+	METHOD_staking_V1_getBlockSigners,
+	METHOD_staking_V2_getBlockSigners,
+	METHOD_staking_V1_getElectedValidatorAddresses,
+	METHOD_staking_V2_getElectedValidatorAddresses,
+	METHOD_trace_block,
+	METHOD_trace_transaction,
+	METHOD_debug_traceTransaction,
+	METHOD_eth_chainId,
+	METHOD_eth_protocolVersion,
+	METHOD_eth_syncing,
+	METHOD_eth_blockNumber,
+	METHOD_eth_gasPrice,
+	METHOD_eth_getBalance,
+	METHOD_eth_getTransactionCount,
+	METHOD_eth_getCode,
+	METHOD_eth_getStorageAt,
+	METHOD_eth_call,
+	METHOD_eth_estimateGas,
+	METHOD_eth_sendRawTransaction,
+	METHOD_eth_getBlockByNumber,
+	METHOD_eth_getBlockByHash,
+	METHOD_eth_getBlockTransactionCountByNumber,
+	METHOD_eth_getBlockTransactionCountByHash,
+	METHOD_eth_getTransactionByHash,
+	METHOD_eth_getTransactionByBlockNumberAndIndex,
+	METHOD_eth_getTransactionByBlockHashAndIndex,
+	METHOD_eth_getTransactionReceipt,
+	METHOD_eth_getLogs,
+	METHOD_eth_newFilter,
+	METHOD_eth_newBlockFilter,
+	METHOD_eth_newPendingTransactionFilter,
+	METHOD_eth_getFilterChanges,
+	METHOD_eth_getFilterLogs,
+	METHOD_eth_uninstallFilter,
+	METHOD_net_version,
+	METHOD_net_listening,
+	METHOD_web3_clientVersion,
+	METHOD_web3_sha3,
+	METHOD_transaction_V1_getStakingTransactionHistory,
+	METHOD_transaction_V2_getStakingTransactionHistory,
+	METHOD_protocol_V2_getHeaderByNumber,
+	METHOD_protocol_V1_getSuperCommittees,
+}
@@ -0,0 +1,316 @@
+package methods
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Category groups methods the same way as the constants above
+type Category string
+
+const (
+	CategoryAccount     Category = "account"
+	CategoryFilter      Category = "filter"
+	CategoryTransaction Category = "transaction"
+	CategoryContract    Category = "contract"
+	CategoryProtocol    Category = "protocol"
+	CategoryStaking     Category = "staking"
+	CategoryTrace       Category = "trace"
+	CategoryEthereum    Category = "ethereum"
+)
+
+// ParamType is the kind of value a param accepts
+type ParamType string
+
+const (
+	// ParamAddress is a bech32 or hex address
+	ParamAddress ParamType = "address"
+	// ParamBlockNumber is a block number, hex in V1 and eth, or a tag such as latest
+	ParamBlockNumber ParamType = "blockNumber"
+	ParamBlockHash   ParamType = "blockHash"
+	ParamTxHash      ParamType = "txHash"
+	ParamIndex       ParamType = "index"
+	ParamEpoch       ParamType = "epoch"
+	ParamInt         ParamType = "int"
+	ParamBool        ParamType = "bool"
+	// ParamData is hex encoded bytes, such as a signed transaction or a storage key
+	ParamData     ParamType = "data"
+	ParamFilterID ParamType = "filterID"
+	// ParamObject is a JSON object, the Go type is named in the param
+	ParamObject ParamType = "object"
+)
+
+// Param describes a single positional param of a method
+type Param struct {
+	Name     string    `json:"name"`
+	Type     ParamType `json:"type"`
+	Optional bool      `json:"optional,omitempty"`
+	// GoType is the struct in the harmony package used for object params
+	GoType string `json:"goType,omitempty"`
+}
+
+// Method is the metadata of a JSON-RPC method
+type Method struct {
+	Name     string   `json:"name"`
+	Category Category `json:"category"`
+	Params   []Param  `json:"params"`
+	// Result is the Go type in the harmony package that the result is decoded into
+	Result string `json:"result"`
+	// Mutates is set on methods that change the chain state
+	Mutates bool `json:"mutates,omitempty"`
+	// Archive is set on methods that need historical state or traces from an archive node
+	Archive bool `json:"archive,omitempty"`
+
+	// Namespace, Version and Counterpart are derived from the name
+	Namespace   string `json:"namespace"`
+	Version     int    `json:"version,omitempty"`
+	Counterpart string `json:"counterpart,omitempty"`
+}
+
+var (
+	address     = Param{Name: "address", Type: ParamAddress}
+	validator   = Param{Name: "validator", Type: ParamAddress}
+	delegator   = Param{Name: "delegator", Type: ParamAddress}
+	blockNumber = Param{Name: "blockNumber", Type: ParamBlockNumber}
+	blockHash   = Param{Name: "blockHash", Type: ParamBlockHash}
+	txHash      = Param{Name: "txHash", Type: ParamTxHash}
+	index       = Param{Name: "index", Type: ParamIndex}
+	filterID    = Param{Name: "filterID", Type: ParamFilterID}
+	logFilter   = Param{Name: "filter", Type: ParamObject, GoType: "LogFilter"}
+	callArgs    = Param{Name: "call", Type: ParamObject, GoType: "RpcCallArgs"}
+	blockArgs   = Param{Name: "blockArgs", Type: ParamObject, GoType: "BlockArgs"}
+	fullTx      = Param{Name: "fullTx", Type: ParamBool}
+	rawTx       = Param{Name: "rawTransaction", Type: ParamData}
+	page        = Param{Name: "page", Type: ParamInt}
+	epoch       = Param{Name: "epoch", Type: ParamEpoch}
+)
+
+// optional returns a copy of p that can be left out
+func optional(p Param) Param {
+	p.Optional = true
+	return p
+}
+
+// registry holds the metadata of every method in All
+var registry = []Method{
+	// Account methods
+	{Name: METHOD_V1_getBalanceByBlockNumber, Category: CategoryAccount, Params: []Param{address, blockNumber}, Result: "hexutil.Big", Archive: true},
+	{Name: METHOD_V2_getBalanceByBlockNumber, Category: CategoryAccount, Params: []Param{address, blockNumber}, Result: "big.Int", Archive: true},
+	{Name: METHOD_V1_getTransactionCount, Category: CategoryAccount, Params: []Param{address, blockNumber}, Result: "hexutil.Uint64"},
+	{Name: METHOD_V2_getTransactionCount, Category: CategoryAccount, Params: []Param{address, blockNumber}, Result: "big.Int"},
+	{Name: METHOD_V1_getBalance, Category: CategoryAccount, Params: []Param{address, blockNumber}, Result: "hexutil.Big"},
+	{Name: METHOD_V2_getBalance, Category: CategoryAccount, Params: []Param{address}, Result: "big.Int"},
+
+	// Filter methods
+	{Name: METHOD_filter_getFilterLogs, Category: CategoryFilter, Params: []Param{filterID}, Result: "[]TransactionLog"},
+	{Name: METHOD_filter_newFilter, Category: CategoryFilter, Params: []Param{logFilter}, Result: "string"},
+	{Name: METHOD_filter_newPendingtransactionFilter, Category: CategoryFilter, Result: "string"},
+	{Name: METHOD_filter_newBlockFilter, Category: CategoryFilter, Result: "string"},
+	{Name: METHOD_filter_getFilterChanges, Category: CategoryFilter, Params: []Param{filterID}, Result: "json.RawMessage"},
+	{Name: METHOD_filter_getLogs, Category: CategoryFilter, Params: []Param{logFilter}, Result: "[]TransactionLog"},
+	{Name: METHOD_filter_uninstallFilter, Category: CategoryFilter, Params: []Param{filterID}, Result: "bool"},
+
+	// Transaction methods
+	{Name: METHOD_transaction_V1_getStakingTransactionByBlockHashAndIndex, Category: CategoryTransaction, Params: []Param{blockHash, index}, Result: "StakingTransactionV1"},
+	{Name: METHOD_transaction_V2_getStakingTransactionByBlockHashAndIndex, Category: CategoryTransaction, Params: []Param{blockHash, index}, Result: "StakingTransactionV2"},
+	{Name: METHOD_transaction_V1_getStakingTransactionByBlockNumberAndIndex, Category: CategoryTransaction, Params: []Param{blockNumber, index}, Result: "StakingTransactionV1"},
+	{Name: METHOD_transaction_V2_getStakingTransactionByBlockNumberAndIndex, Category: CategoryTransaction, Params: []Param{blockNumber, index}, Result: "StakingTransactionV2"},
+	{Name: METHOD_transaction_V1_getStakingTransactionByHash, Category: CategoryTransaction, Params: []Param{txHash}, Result: "StakingTransactionV1"},
+	{Name: METHOD_transaction_V2_getStakingTransactionByHash, Category: CategoryTransaction, Params: []Param{txHash}, Result: "StakingTransactionV2"},
+	{Name: METHOD_transaction_V1_getCurrentTransactionErrorSink, Category: CategoryTransaction, Result: "[]ErrorSinkLog"},
+	{Name: METHOD_transaction_V2_getCurrentTransactionErrorSink, Category: CategoryTransaction, Result: "[]ErrorSinkLog"},
+	{Name: METHOD_transaction_V1_getPendingCrossLinks, Category: CategoryTransaction, Result: "json.RawMessage"},
+	{Name: METHOD_transaction_V2_getPendingCrossLinks, Category: CategoryTransaction, Result: "json.RawMessage"},
+	{Name: METHOD_transaction_V1_getPendingCXReceipts, Category: CategoryTransaction, Result: "[]PendingCXReceipt"},
+	{Name: METHOD_transaction_V2_getPendingCXReceipts, Category: CategoryTransaction, Result: "[]PendingCXReceipt"},
+	{Name: METHOD_transaction_V1_getCXReceiptByHash, Category: CategoryTransaction, Params: []Param{txHash}, Result: "RecieptV1"},
+	{Name: METHOD_transaction_V2_getCXReceiptByHash, Category: CategoryTransaction, Params: []Param{txHash}, Result: "Reciept"},
+	{Name: METHOD_transaction_V1_pendingTransactions, Category: CategoryTransaction, Result: "[]TransactionByHashV1"},
+	{Name: METHOD_transaction_V2_pendingTransactions, Category: CategoryTransaction, Result: "[]TransactionByHashV2"},
+	{Name: METHOD_transaction_sendRawStakingTransaction, Category: CategoryTransaction, Params: []Param{rawTx}, Result: "string", Mutates: true},
+	{Name: METHOD_transaction_sendRawTransaction, Category: CategoryTransaction, Params: []Param{rawTx}, Result: "string", Mutates: true},
+	{Name: METHOD_transaction_V1_getTransactionHistory, Category: CategoryTransaction, Params: []Param{{Name: "args", Type: ParamObject, GoType: "TransactionArguments"}}, Result: "TransactionHistoryV1"},
+	{Name: METHOD_transaction_V2_getTransactionHistory, Category: CategoryTransaction, Params: []Param{{Name: "args", Type: ParamObject, GoType: "TransactionArguments"}}, Result: "TransactionHistoryV2"},
+	{Name: METHOD_transaction_V1_getStakingTransactionHistory, Category: CategoryTransaction, Params: []Param{{Name: "args", Type: ParamObject, GoType: "TransactionArguments"}}, Result: "StakingTransactionHistoryV1"},
+	{Name: METHOD_transaction_V2_getStakingTransactionHistory, Category: CategoryTransaction, Params: []Param{{Name: "args", Type: ParamObject, GoType: "TransactionArguments"}}, Result: "StakingTransactionHistoryV2"},
+	{Name: METHOD_transaction_V1_getTransactionReceipt, Category: CategoryTransaction, Params: []Param{txHash}, Result: "TransactionReceipt_V1"},
+	{Name: METHOD_transaction_V2_getTransactionReceipt, Category: CategoryTransaction, Params: []Param{txHash}, Result: "TransactionReceipt_V2"},
+	{Name: METHOD_transaction_V1_getBlockTransactionCountByHash, Category: CategoryTransaction, Params: []Param{blockHash}, Result: "hexutil.Uint64"},
+	{Name: METHOD_transaction_V2_getBlockTransactionCountByHash, Category: CategoryTransaction, Params: []Param{blockHash}, Result: "int64"},
+	{Name: METHOD_transaction_V1_getBlockTransactionCountByNumber, Category: CategoryTransaction, Params: []Param{blockNumber}, Result: "hexutil.Uint64"},
+	{Name: METHOD_transaction_V2_getBlockTransactionCountByNumber, Category: CategoryTransaction, Params: []Param{blockNumber}, Result: "int64"},
+	{Name: METHOD_transaction_V1_getTransactionByHash, Category: CategoryTransaction, Params: []Param{txHash}, Result: "TransactionByHashV1"},
+	{Name: METHOD_transaction_V2_getTransactionByHash, Category: CategoryTransaction, Params: []Param{txHash}, Result: "TransactionByHashV2"},
+	{Name: METHOD_transaction_V1_getTransactionByBlockNumberAndIndex, Category: CategoryTransaction, Params: []Param{blockNumber, index}, Result: "TransactionByHashV1"},
+	{Name: METHOD_transaction_V2_getTransactionByBlockNumberAndIndex, Category: CategoryTransaction, Params: []Param{blockNumber, index}, Result: "TransactionByHashV2"},
+	{Name: METHOD_transaction_V1_getTransactionByBlockHashAndIndex, Category: CategoryTransaction, Params: []Param{blockHash, index}, Result: "TransactionByHashV1"},
+	{Name: METHOD_transaction_V2_getTransactionByBlockHashAndIndex, Category: CategoryTransaction, Params: []Param{blockHash, index}, Result: "TransactionByHashV2"},
+	{Name: METHOD_transaction_V1_getBlockByNumber, Category: CategoryTransaction, Params: []Param{blockNumber, fullTx}, Result: "BlockV1"},
+	{Name: METHOD_transaction_V2_getBlockByNumber, Category: CategoryTransaction, Params: []Param{blockNumber, blockArgs}, Result: "BlockV2"},
+	{Name: METHOD_transaction_V1_getBlockByHash, Category: CategoryTransaction, Params: []Param{blockHash, fullTx}, Result: "BlockV1"},
+	{Name: METHOD_transaction_V2_getBlockByHash, Category: CategoryTransaction, Params: []Param{blockHash, blockArgs}, Result: "BlockV2"},
+	{Name: METHOD_transaction_V1_getBlocks, Category: CategoryTransaction, Params: []Param{{Name: "from", Type: ParamBlockNumber}, {Name: "to", Type: ParamBlockNumber}, optional(blockArgs)}, Result: "[]BlockV1"},
+	{Name: METHOD_transaction_V2_getBlocks, Category: CategoryTransaction, Params: []Param{{Name: "from", Type: ParamBlockNumber}, {Name: "to", Type: ParamBlockNumber}, blockArgs}, Result: "[]BlockV2"},
+	{Name: METHOD_transaction_tx, Category: CategoryTransaction, Params: []Param{txHash}, Result: "Transaction"},
+
+	// Contract methods
+	{Name: METHOD_contract_getStorageAt, Category: CategoryContract, Params: []Param{address, {Name: "key", Type: ParamData}, blockNumber}, Result: "string"},
+	{Name: METHOD_contract_getCode, Category: CategoryContract, Params: []Param{address, blockNumber}, Result: "string"},
+	{Name: METHOD_contract_call, Category: CategoryContract, Params: []Param{callArgs, blockNumber}, Result: "string"},
+	{Name: METHOD_contract_estimateGas, Category: CategoryContract, Params: []Param{callArgs}, Result: "string"},
+
+	// Protocol methods
+	{Name: METHOD_protocol_isLastBlock, Category: CategoryProtocol, Params: []Param{blockNumber}, Result: "bool"},
+	{Name: METHOD_protocol_epochLastBlock, Category: CategoryProtocol, Params: []Param{epoch}, Result: "int64"},
+	{Name: METHOD_protocol_lastestHeader, Category: CategoryProtocol, Result: "NetworkHeader"},
+	{Name: METHOD_protocol_V2_getHeaderByNumber, Category: CategoryProtocol, Params: []Param{blockNumber}, Result: "NetworkHeader"},
+	{Name: METHOD_protocol_getShardingStructure, Category: CategoryProtocol, Result: "shardingStructure"},
+	{Name: METHOD_protocol_V1_blockNumber, Category: CategoryProtocol, Result: "hexutil.Uint64"},
+	{Name: METHOD_protocol_V2_blockNumber, Category: CategoryProtocol, Result: "int64"},
+	{Name: METHOD_protocol_syncing, Category: CategoryProtocol, Result: "json.RawMessage"},
+	{Name: METHOD_protocol_V1_gasPrice, Category: CategoryProtocol, Result: "hexutil.Big"},
+	{Name: METHOD_protocol_V2_gasPrice, Category: CategoryProtocol, Result: "big.Int"},
+	{Name: METHOD_protocol_peerCount, Category: CategoryProtocol, Result: "string"},
+	{Name: METHOD_protocol_V1_getEpoch, Category: CategoryProtocol, Result: "hexutil.Uint64"},
+	{Name: METHOD_protocol_V2_getEpoch, Category: CategoryProtocol, Result: "big.Int"},
+	{Name: METHOD_protocol_getLeader, Category: CategoryProtocol, Result: "string"},
+	{Name: METHOD_protocol_V1_getSuperCommittees, Category: CategoryProtocol, Result: "SuperCommittees"},
+	{Name: METHOD_protocol_V2_getSuperCommittees, Category: CategoryProtocol, Result: "SuperCommittees"},
+
+	// Staking methods
+	{Name: METHOD_staking_getCirculatingSupply, Category: CategoryStaking, Result: "string"},
+	{Name: METHOD_staking_getTotalSupply, Category: CategoryStaking, Result: "big.Int"},
+	{Name: METHOD_staking_getStakingNetworkInfo, Category: CategoryStaking, Result: "NetworkStakingInfo"},
+	{Name: METHOD_staking_getAllValidatorInformation, Category: CategoryStaking, Params: []Param{page}, Result: "[]ValidatorInfo"},
+	{Name: METHOD_staking_getAllValidatorInformationByBlockNumber, Category: CategoryStaking, Params: []Param{page, blockNumber}, Result: "[]ValidatorInfo", Archive: true},
+	{Name: METHOD_staking_getCurrentUtilityMetrics, Category: CategoryStaking, Result: "UtilityMetrics"},
+	{Name: METHOD_staking_getDelegationsByValidator, Category: CategoryStaking, Params: []Param{validator}, Result: "[]DelegationByValidator"},
+	{Name: METHOD_staking_getDelegationsByDelegatorAndValidator, Category: CategoryStaking, Params: []Param{delegator, validator}, Result: "[]DelegationByValidator"},
+	{Name: METHOD_staking_getDelegationsByDelegator, Category: CategoryStaking, Params: []Param{delegator}, Result: "[]DelegationByValidator"},
+	{Name: METHOD_staking_getValidatorMetrics, Category: CategoryStaking, Params: []Param{validator}, Result: "ValidatorMetrics"},
+	{Name: METHOD_staking_getMedianRawStakeSnapshot, Category: CategoryStaking, Result: "MedianRawStakeSnapshot"},
+	{Name: METHOD_staking_getActiveValidatorAddresses, Category: CategoryStaking, Result: "[]string"},
+	{Name: METHOD_staking_V1_getAllValidatorAddresses, Category: CategoryStaking, Result: "[]string"},
+	{Name: METHOD_staking_V2_getAllValidatorAddresses, Category: CategoryStaking, Result: "[]string"},
+	{Name: METHOD_staking_V1_getCurrentStakingErrorSink, Category: CategoryStaking, Result: "[]ErrorSinkLog"},
+	{Name: METHOD_staking_V2_getCurrentStakingErrorSink, Category: CategoryStaking, Result: "[]ErrorSinkLog"},
+	{Name: METHOD_staking_getValidatorInformation, Category: CategoryStaking, Params: []Param{validator}, Result: "ValidatorInfo"},
+	{Name: METHOD_staking_V1_getValidators, Category: CategoryStaking, Params: []Param{epoch}, Result: "GetValidatorsV1"},
+	{Name: METHOD_staking_V2_getValidators, Category: CategoryStaking, Params: []Param{epoch}, Result: "GetValidatorsV2"},
+	{Name: METHOD_staking_getSignedBlocks, Category: CategoryStaking, Params: []Param{validator}, Result: "string"},
+	{Name: METHOD_staking_V1_isBlockSigner, Category: CategoryStaking, Params: []Param{blockNumber, validator}, Result: "bool"},
+	{Name: METHOD_staking_V2_isBlockSigner, Category: CategoryStaking, Params: []Param{blockNumber, validator}, Result: "bool"},
+	{Name: METHOD_staking_V1_getBlockSigners, Category: CategoryStaking, Params: []Param{blockNumber}, Result: "[]string"},
+	{Name: METHOD_staking_V2_getBlockSigners, Category: CategoryStaking, Params: []Param{blockNumber}, Result: "[]string"},
+	{Name: METHOD_staking_V1_getElectedValidatorAddresses, Category: CategoryStaking, Result: "[]string"},
+	{Name: METHOD_staking_V2_getElectedValidatorAddresses, Category: CategoryStaking, Result: "[]string"},
+
+	// Tracing methods
+	{Name: METHOD_trace_block, Category: CategoryTrace, Params: []Param{blockNumber}, Result: "[]TraceBlock", Archive: true},
+	{Name: METHOD_trace_transaction, Category: CategoryTrace, Params: []Param{txHash}, Result: "[]TraceBlock", Archive: true},
+	{Name: METHOD_debug_traceTransaction, Category: CategoryTrace, Params: []Param{txHash, optional(Param{Name: "config", Type: ParamObject, GoType: "TraceConfig"})}, Result: "CallFrame", Archive: true},
+
+	// Ethereum compatible methods
+	{Name: METHOD_eth_chainId, Category: CategoryEthereum, Result: "hexutil.Big"},
+	{Name: METHOD_eth_protocolVersion, Category: CategoryEthereum, Result: "json.RawMessage"},
+	{Name: METHOD_eth_syncing, Category: CategoryEthereum, Result: "json.RawMessage"},
+	{Name: METHOD_eth_blockNumber, Category: CategoryEthereum, Result: "hexutil.Uint64"},
+	{Name: METHOD_eth_gasPrice, Category: CategoryEthereum, Result: "hexutil.Big"},
+	{Name: METHOD_eth_getBalance, Category: CategoryEthereum, Params: []Param{address, blockNumber}, Result: "hexutil.Big"},
+	{Name: METHOD_eth_getTransactionCount, Category: CategoryEthereum, Params: []Param{address, blockNumber}, Result: "hexutil.Uint64"},
+	{Name: METHOD_eth_getCode, Category: CategoryEthereum, Params: []Param{address, blockNumber}, Result: "hexutil.Bytes"},
+	{Name: METHOD_eth_getStorageAt, Category: CategoryEthereum, Params: []Param{address, {Name: "key", Type: ParamData}, blockNumber}, Result: "hexutil.Bytes"},
+	{Name: METHOD_eth_call, Category: CategoryEthereum, Params: []Param{callArgs, blockNumber}, Result: "hexutil.Bytes"},
+	{Name: METHOD_eth_estimateGas, Category: CategoryEthereum, Params: []Param{callArgs}, Result: "hexutil.Uint64"},
+	{Name: METHOD_eth_sendRawTransaction, Category: CategoryEthereum, Params: []Param{rawTx}, Result: "string", Mutates: true},
+	{Name: METHOD_eth_getBlockByNumber, Category: CategoryEthereum, Params: []Param{blockNumber, fullTx}, Result: "EthBlock"},
+	{Name: METHOD_eth_getBlockByHash, Category: CategoryEthereum, Params: []Param{blockHash, fullTx}, Result: "EthBlock"},
+	{Name: METHOD_eth_getBlockTransactionCountByNumber, Category: CategoryEthereum, Params: []Param{blockNumber}, Result: "hexutil.Uint64"},
+	{Name: METHOD_eth_getBlockTransactionCountByHash, Category: CategoryEthereum, Params: []Param{blockHash}, Result: "hexutil.Uint64"},
+	{Name: METHOD_eth_getTransactionByHash, Category: CategoryEthereum, Params: []Param{txHash}, Result: "EthTransaction"},
+	{Name: METHOD_eth_getTransactionByBlockNumberAndIndex, Category: CategoryEthereum, Params: []Param{blockNumber, index}, Result: "EthTransaction"},
+	{Name: METHOD_eth_getTransactionByBlockHashAndIndex, Category: CategoryEthereum, Params: []Param{blockHash, index}, Result: "EthTransaction"},
+	{Name: METHOD_eth_getTransactionReceipt, Category: CategoryEthereum, Params: []Param{txHash}, Result: "EthReceipt"},
+	{Name: METHOD_eth_getLogs, Category: CategoryEthereum, Params: []Param{logFilter}, Result: "[]TransactionLog"},
+	{Name: METHOD_eth_newFilter, Category: CategoryEthereum, Params: []Param{logFilter}, Result: "string"},
+	{Name: METHOD_eth_newBlockFilter, Category: CategoryEthereum, Result: "string"},
+	{Name: METHOD_eth_newPendingTransactionFilter, Category: CategoryEthereum, Result: "string"},
+	{Name: METHOD_eth_getFilterChanges, Category: CategoryEthereum, Params: []Param{filterID}, Result: "json.RawMessage"},
+	{Name: METHOD_eth_getFilterLogs, Category: CategoryEthereum, Params: []Param{filterID}, Result: "[]TransactionLog"},
+	{Name: METHOD_eth_uninstallFilter, Category: CategoryEthereum, Params: []Param{filterID}, Result: "bool"},
+	{Name: METHOD_net_version, Category: CategoryEthereum, Result: "string"},
+	{Name: METHOD_net_listening, Category: CategoryEthereum, Result: "bool"},
+	{Name: METHOD_web3_clientVersion, Category: CategoryEthereum, Result: "string"},
+	{Name: METHOD_web3_sha3, Category: CategoryEthereum, Params: []Param{{Name: "data", Type: ParamData}}, Result: "hexutil.Bytes"},
+}
+
+// Registry is the metadata of every method in All keyed by method name
+var Registry = map[string]Method{}
+
+func init() {
+	for _, m := range registry {
+		m.Namespace, m.Version = namespaceOf(m.Name)
+		Registry[m.Name] = m
+	}
+	for name, m := range Registry {
+		if m.Version == 0 {
+			continue
+		}
+		counterpart := counterpartOf(name)
+		if _, ok := Registry[counterpart]; ok {
+			m.Counterpart = counterpart
+			Registry[name] = m
+		}
+	}
+	// The registry is the source of truth, a method without metadata is a programming error
+	for _, name := range All {
+		if _, ok := Registry[name]; !ok {
+			panic(fmt.Sprintf("method %s is missing from the registry", name))
+		}
+	}
+}
+
+// namespaceOf splits the namespace from the method name, hmy is API version 1 and hmyv2 version 2
+func namespaceOf(name string) (string, int) {
+	namespace := name
+	if i := strings.Index(name, "_"); i >= 0 {
+		namespace = name[:i]
+	}
+	switch namespace {
+	case "hmy":
+		return namespace, 1
+	case "hmyv2":
+		return namespace, 2
+	}
+	return namespace, 0
+}
+
+// counterpartOf returns the name of the same method in the other API version
+func counterpartOf(name string) string {
+	if strings.HasPrefix(name, "hmyv2_") {
+		return "hmy_" + strings.TrimPrefix(name, "hmyv2_")
+	}
+	return "hmyv2_" + strings.TrimPrefix(name, "hmy_")
+}
+
+// Lookup returns the metadata of the method
+func Lookup(name string) (Method, bool) {
+	m, ok := Registry[name]
+	return m, ok
+}
+
+// Registered returns every method in the registry sorted by category and name
+func Registered() []Method {
+	list := make([]Method, 0, len(Registry))
+	for _, m := range Registry {
+		list = append(list, m)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Category != list[j].Category {
+			return list[i].Category < list[j].Category
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list
+}